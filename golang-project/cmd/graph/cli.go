@@ -0,0 +1,1960 @@
+package main
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"math"
+	"os"
+	"path/filepath"
+	"runtime"
+	"strconv"
+	"strings"
+	"syscall"
+	"unicode/utf8"
+
+	"github.com/NomenConservandum/graph-theory/golang-project/graph"
+)
+
+type CLI struct {
+	workspace     *graph.Workspace
+	workspacePath string // the file the workspace was opened from or saved to, "" if none
+	savedState    []byte // the workspace as it was saved or opened, to find unsaved changes
+	recoveryState []byte // the workspace as it was written to the recovery file last
+}
+
+func NewCLI() *CLI {
+	return &CLI{
+		workspace: graph.NewWorkspace(),
+	}
+}
+
+func (c *CLI) printMainMenu() {
+	fmt.Println("\n=== Main Menu ===")
+	if active := c.workspace.ActiveGraph(); active != nil {
+		fmt.Printf("Active graph: %s\n", active.Name)
+	}
+	fmt.Println("1. Select graph to work with")
+	fmt.Println("2. Add a graph")
+	fmt.Println("3. Rename a graph")
+	fmt.Println("4. Open workspace")
+	fmt.Println("5. Save workspace")
+	fmt.Printf("6. Autosave workspace on exit (now %s)\n", map[bool]string{true: "on", false: "off"}[c.workspace.Autosave])
+	fmt.Println("7. Exit")
+	fmt.Print("Choose an option: ")
+}
+
+func (c *CLI) addGraphMenu() {
+	fmt.Println("\n=== Add Graph ===")
+	fmt.Println("1. Create graph manually")
+	fmt.Println("2. Load graph from file")
+	fmt.Println("3. Back to main menu")
+	fmt.Print("Choose an option: ")
+}
+
+func (c *CLI) selectGraph() {
+	graphs := c.workspace.Graphs
+	if len(graphs) == 0 {
+		fmt.Println("No graphs available. Please add a graph first.")
+		return
+	}
+
+	fmt.Println("\n=== Select Graph ===")
+	for i, entry := range graphs {
+		g := entry.Graph
+		graphType := "Undirected"
+		if g.IsMixed() {
+			graphType = "Mixed"
+		} else if g.IsOriented() {
+			graphType = "Directed"
+		}
+		weightType := "Unweighted"
+		if g.IsWeighted() {
+			weightType = "Weighted"
+		}
+		fmt.Printf("%d. %s: %s %s Graph (%d vertices, %d edges)\n",
+			i+1, entry.Name, graphType, weightType, len(g.Nodes()), c.countEdges(g))
+	}
+	fmt.Printf("%d. Back to main menu\n", len(graphs)+1)
+	fmt.Print("Choose a graph: ")
+
+	var input string
+	fmt.Scanln(&input)
+	choice, err := strconv.Atoi(strings.TrimSpace(input))
+	if err != nil {
+		fmt.Println("Invalid input.")
+		return
+	}
+
+	if choice == len(graphs)+1 {
+		return
+	}
+
+	if choice < 1 || choice > len(graphs) {
+		fmt.Println("Invalid graph selection.")
+		return
+	}
+
+	c.workspace.Active = choice - 1
+	fmt.Printf("Selected graph %d: %s\n", choice, graphs[choice-1].Name)
+	c.graphOperationsMenu()
+}
+
+func (c *CLI) countEdges(g *graph.GraphInfo) int {
+	return len(graph.GetAllEdges(g))
+}
+
+// printSaved reports that the graph was written to the file at path
+func (c *CLI) printSaved(g *graph.GraphInfo, path string) {
+	fmt.Printf("Graph successfully saved to %s: %d vertices, %d edges\n", path, len(g.Nodes()), c.countEdges(g))
+}
+
+func (c *CLI) addGraph() {
+	for {
+		c.addGraphMenu()
+
+		var input string
+		fmt.Scanln(&input)
+		choice, err := strconv.Atoi(strings.TrimSpace(input))
+		if err != nil {
+			fmt.Println("Invalid input.")
+			continue
+		}
+
+		switch choice {
+		case 1:
+			c.createGraphManually()
+		case 2:
+			c.loadGraphFromFile()
+		case 3:
+			return
+		default:
+			fmt.Println("Invalid option.")
+		}
+	}
+}
+
+func (c *CLI) createGraphManually() {
+	var input string
+
+	fmt.Print("Is the graph oriented? (y/n, m - mixed): ")
+	fmt.Scanln(&input)
+	mixed := strings.ToLower(strings.TrimSpace(input)) == "m"
+	oriented := strings.ToLower(strings.TrimSpace(input)) == "y" || mixed
+
+	fmt.Print("Is the graph weighted? (y/n): ")
+	fmt.Scanln(&input)
+	weighted := strings.ToLower(strings.TrimSpace(input)) == "y"
+
+	fmt.Print("Allow parallel edges (multigraph)? (y/n): ")
+	fmt.Scanln(&input)
+	multi := strings.ToLower(strings.TrimSpace(input)) == "y"
+
+	var newGraph *graph.GraphInfo
+	if multi {
+		newGraph = graph.MultigraphConstructor(oriented, weighted)
+	} else {
+		newGraph = graph.GraphConstructor(oriented, weighted)
+	}
+	newGraph.SetMixed(mixed)
+
+	// The name may contain spaces, so the whole line is read
+	name := c.workspace.UniqueName(fmt.Sprintf("Graph %d", len(c.workspace.Graphs)+1))
+	fmt.Printf("Enter graph name (Enter - %s): ", name)
+	if input := readLine(); input != "" {
+		name = input
+	}
+	entry := c.workspace.Add(name, newGraph)
+
+	directionType := map[bool]string{true: "directed", false: "undirected"}[oriented]
+	if mixed {
+		directionType = "mixed"
+	}
+	fmt.Printf("Created new %s %s graph '%s'\n",
+		directionType,
+		map[bool]string{true: "weighted", false: "unweighted"}[weighted], entry.Name)
+
+	c.graphOperationsMenu()
+}
+
+// fileFormatsHint lists the file formats chosen by the extension of the path
+const fileFormatsHint = ".dot/.gv - Graphviz, .graphml - GraphML, .gexf - GEXF, .json - JSON, .max/.sp/.gr/.dimacs - DIMACS, .csv - edge list, .svg - SVG, .typ - CeTZ, .tikz/.tex - TikZ, .mmd - Mermaid and .puml - PlantUML (save only), other - text or, when loading, detected from the content"
+
+// printReadError prints the error of reading a graph file, with a hint if the file is a workspace
+func printReadError(err error) {
+	fmt.Println(err.Error())
+	if errors.Is(err, graph.ErrWorkspace) {
+		fmt.Println("Open it with 'Open workspace' in the main menu")
+	}
+}
+
+// readGraphFile reads the graph file at path in the format of its extension or, if it is unknown,
+// of its content (see graph.FileFormat). A CSV edge list is read with the columns chosen by the user.
+// If some lines of a text file can not be read, offers to load it anyway without them.
+// Returns nil if the graph was not loaded
+func (c *CLI) readGraphFile(path string) *graph.GraphInfo {
+	if strings.EqualFold(filepath.Ext(path), ".csv") {
+		return c.readCSVEdgeList(path)
+	}
+	format, err := graph.FileFormat(path)
+	if err != nil {
+		printReadError(err)
+		return nil
+	}
+	if format.Name != "text" {
+		g, err := graph.ReadFile(path, format.Name)
+		if err != nil {
+			printReadError(err)
+			return nil
+		}
+		fmt.Printf("Graph loaded from %s: %d vertices, %d edges\n", path, len(g.Nodes()), c.countEdges(g))
+		return g
+	}
+
+	g, err := graph.ReadGraphFile(path)
+	if err == nil {
+		fmt.Printf("Graph loaded from %s: %d vertices, %d edges\n", path, len(g.Nodes()), c.countEdges(g))
+		return g
+	}
+	fmt.Println(err.Error())
+
+	var parseErr *graph.ParseError
+	if !errors.As(err, &parseErr) {
+		return nil
+	}
+
+	var input string
+	fmt.Print("Load the file skipping the lines that can not be read? (y/n): ")
+	fmt.Scanln(&input)
+	if strings.ToLower(strings.TrimSpace(input)) != "y" {
+		return nil
+	}
+
+	g, warnings, err := graph.ReadGraphFileLenient(path)
+	if err != nil {
+		fmt.Println(err.Error())
+		return nil
+	}
+	for _, warning := range warnings {
+		fmt.Printf("Skipped %v\n", warning)
+	}
+	fmt.Printf("Graph loaded from %s: %d vertices, %d edges\n", path, len(g.Nodes()), c.countEdges(g))
+	return g
+}
+
+func (c *CLI) loadGraphFromFile() {
+	var input string
+	fmt.Printf("Enter file path (%s): ", fileFormatsHint)
+	fmt.Scanln(&input)
+	path := strings.TrimSpace(input)
+
+	if path == "" {
+		fmt.Println("No file path provided")
+		return
+	}
+
+	if _, err := os.Stat(path); os.IsNotExist(err) {
+		fmt.Printf("File '%s' does not exist\n", path)
+		return
+	}
+
+	newGraph := c.readGraphFile(path)
+	if newGraph != nil {
+		// The graph is named after the file
+		entry := c.workspace.Add(strings.TrimSuffix(filepath.Base(path), filepath.Ext(path)), newGraph)
+		entry.Source = path
+		fmt.Printf("Graph successfully loaded from %s as '%s'\n", path, entry.Name)
+		c.graphOperationsMenu()
+	} else {
+		fmt.Printf("Failed to load graph from %s\n", path)
+	}
+}
+
+// Updated methods to accept graph as parameter
+func (c *CLI) addVertex(g *graph.GraphInfo) {
+	var input string
+	fmt.Print("Enter vertex value: ")
+	fmt.Scanln(&input)
+	value := strings.TrimSpace(input)
+
+	node := graph.NodeConstructor(value)
+	if err := graph.AddVertex(g, node); err != nil {
+		fmt.Println(err.Error())
+		return
+	}
+	fmt.Printf("Vertex '%s' added successfully\n", value)
+}
+
+func (c *CLI) addEdge(g *graph.GraphInfo) {
+	if len(g.Nodes()) < 2 {
+		fmt.Println("Need at least 2 vertices to add an edge")
+		return
+	}
+
+	c.listVertices(g)
+
+	node1 := c.readVertex(g, "Enter first vertex id: ")
+	if node1 == nil {
+		fmt.Println("Invalid vertex id")
+		return
+	}
+
+	node2 := c.readVertex(g, "Enter second vertex id: ")
+	if node2 == nil {
+		fmt.Println("Invalid vertex id")
+		return
+	}
+
+	var input string
+	var weight float64 = 0
+	if g.IsWeighted() {
+		fmt.Print("Enter edge weight: ")
+		fmt.Scanln(&input)
+		var err error
+		weight, _, err = g.ParseWeight(strings.TrimSpace(input))
+		if err != nil {
+			fmt.Println("Invalid weight, using 0")
+			weight = 0
+		}
+	}
+
+	oriented := g.IsOriented()
+	if g.IsMixed() {
+		fmt.Print("Is the edge oriented? (y/n): ")
+		fmt.Scanln(&input)
+		oriented = strings.ToLower(strings.TrimSpace(input)) == "y"
+	}
+
+	if oriented {
+		err := graph.AddEdge(g, node1, node2, weight)
+		if err != nil {
+			fmt.Println(err.Error())
+			return
+		}
+		fmt.Printf("Added oriented edge from '%v' to '%v'", node1.Value, node2.Value)
+		if g.IsWeighted() {
+			fmt.Printf(" with weight %.2f", weight)
+		}
+		fmt.Println()
+	} else {
+		if g.IsWeighted() {
+			err := graph.AddNonOrientedEdge(g, node1, node2, weight)
+			if err != nil {
+				fmt.Println(err.Error())
+				return
+			}
+		} else {
+			err := graph.AddNonOrientedNonWeightedEdge(g, node1, node2)
+			if err != nil {
+				fmt.Println(err.Error())
+				return
+			}
+		}
+		fmt.Printf("Added non-oriented edge between '%v' and '%v'", node1.Value, node2.Value)
+		if g.IsWeighted() {
+			fmt.Printf(" with weight %.2f", weight)
+		}
+		fmt.Println()
+	}
+}
+
+func (c *CLI) removeVertex(g *graph.GraphInfo) {
+	if len(g.Nodes()) == 0 {
+		fmt.Println("No vertices to remove")
+		return
+	}
+
+	c.listVertices(g)
+
+	node := c.readVertex(g, "Enter vertex id to remove: ")
+	if node == nil {
+		fmt.Println("Invalid vertex id")
+		return
+	}
+
+	graph.RemoveVertex(g, node)
+	fmt.Printf("Vertex '%v' removed successfully\n", node.Value)
+}
+
+func (c *CLI) removeEdge(g *graph.GraphInfo) {
+	if c.countEdges(g) == 0 {
+		fmt.Println("No edges to remove")
+		return
+	}
+
+	err := c.listEdges(g, true)
+	if err != nil {
+		return
+	}
+
+	var input string
+	fmt.Print("Enter edge id to remove: ")
+	fmt.Scanln(&input)
+	id, err := strconv.ParseUint(strings.TrimSpace(input), 10, 32)
+	edge := g.EdgeById(uint32(id))
+	if err != nil || edge == nil {
+		fmt.Println("Invalid edge id")
+		return
+	}
+
+	graph.RemoveEdge(g, edge)
+	fmt.Printf("Edge from '%v' to '%v' has been removed successfully\n", edge.List[0].Value, edge.List[1].Value)
+}
+
+func (c *CLI) listVertices(g *graph.GraphInfo) {
+	fmt.Println("\nVertices:")
+	if len(g.Nodes()) == 0 {
+		fmt.Println("No vertices")
+		return
+	}
+
+	for _, node := range g.Nodes() {
+		fmt.Printf("%d: %v\n", node.Id, node.Value)
+	}
+}
+
+// readVertex asks for a vertex id. Returns nil if there is no vertex with such id
+func (c *CLI) readVertex(g *graph.GraphInfo, prompt string) *graph.Node {
+	var input string
+	fmt.Print(prompt)
+	fmt.Scanln(&input)
+	return c.parseVertex(g, input)
+}
+
+// parseVertex returns the vertex with id written in input or nil if there is none
+func (c *CLI) parseVertex(g *graph.GraphInfo, input string) *graph.Node {
+	id, err := strconv.ParseUint(strings.TrimSpace(input), 10, 32)
+	if err != nil {
+		return nil
+	}
+	return g.NodeById(uint32(id))
+}
+
+func (c *CLI) listEdges(g *graph.GraphInfo, mode bool) error {
+	fmt.Println("\nEdges:")
+	if c.countEdges(g) == 0 {
+		fmt.Println("No edges")
+		return fmt.Errorf("no edges")
+	}
+
+	edgeCount := 0
+
+	for _, edge := range graph.GetAllEdges(g) {
+		if mode {
+			fmt.Printf("%d. ", edge.Id)
+		}
+		if edge.IsOriented() {
+			fmt.Printf("From '%v' to '%v'", edge.List[0].Value, edge.List[1].Value)
+		} else {
+			fmt.Printf("Between '%v' and '%v'", edge.List[0].Value, edge.List[1].Value)
+		}
+		if g.IsWeighted() {
+			fmt.Printf(" (weight: %.2f)", edge.Weight)
+		}
+		fmt.Println()
+		edgeCount++
+	}
+
+	if edgeCount == 0 {
+		fmt.Println("No edges")
+		return fmt.Errorf("no edges")
+	}
+	return nil
+}
+
+func (c *CLI) changeGraphType(g *graph.GraphInfo) {
+	var input string
+	fmt.Print("Is the graph oriented? (y/n, m - mixed): ")
+	fmt.Scanln(&input)
+	answer := strings.ToLower(strings.TrimSpace(input))
+	mixed := answer == "m"
+	oriented := answer == "y" || mixed
+
+	fmt.Print("Is the graph weighted? (y/n): ")
+	fmt.Scanln(&input)
+	weighted := strings.ToLower(strings.TrimSpace(input)) == "y"
+
+	reports := make([]*graph.ConversionReport, 0)
+
+	switch {
+	case mixed:
+		// Every edge keeps its direction, only the text format changes
+		g.SetMixed(true)
+	case oriented && (!g.IsOriented() || g.IsMixed()):
+		fmt.Print("Turn every non-oriented edge into both arcs? (y - both arcs, n - orient it from the first end): ")
+		fmt.Scanln(&input)
+		bothArcs := strings.ToLower(strings.TrimSpace(input)) == "y"
+		reports = append(reports, graph.ConvertToDirected(g, bothArcs))
+	case !oriented && g.IsOriented():
+		fmt.Print("Weight of merged opposite edges (min/max/sum): ")
+		fmt.Scanln(&input)
+		policy := graph.WeightMin
+		switch strings.ToLower(strings.TrimSpace(input)) {
+		case "max":
+			policy = graph.WeightMax
+		case "sum":
+			policy = graph.WeightSum
+		}
+		reports = append(reports, graph.ConvertToUndirected(g, policy))
+	}
+
+	if weighted && !g.IsWeighted() {
+		fmt.Print("Enter the default weight of the edges: ")
+		fmt.Scanln(&input)
+		defaultWeight, err := strconv.ParseFloat(strings.TrimSpace(input), 64)
+		if err != nil {
+			fmt.Println("Invalid weight, using 1")
+			defaultWeight = 1
+		}
+		reports = append(reports, graph.ConvertToWeighted(g, defaultWeight))
+	} else if !weighted && g.IsWeighted() {
+		reports = append(reports, graph.ConvertToUnweighted(g))
+	}
+
+	fmt.Printf("Graph type changed: oriented=%v, mixed=%v, weighted=%v\n", g.IsOriented(), g.IsMixed(), g.IsWeighted())
+	for _, report := range reports {
+		fmt.Println(report)
+	}
+}
+
+func (c *CLI) printGraphInfo(g *graph.GraphInfo) {
+	fmt.Println("\nGraph Information:")
+	orientation := map[bool]string{true: "Oriented", false: "Non-oriented"}[g.IsOriented()]
+	if g.IsMixed() {
+		orientation = "Mixed"
+	}
+	fmt.Printf("Type: %s, %s\n",
+		orientation,
+		map[bool]string{true: "Weighted", false: "Non-weighted"}[g.IsWeighted()])
+	if g.IsMultigraph() {
+		fmt.Println("Parallel edges: allowed (multigraph)")
+	}
+	fmt.Printf("Number of vertices: %d\n", len(g.Nodes()))
+	fmt.Printf("Number of edges: %d\n", c.countEdges(g))
+}
+
+func (c *CLI) loadFromFile(g *graph.GraphInfo) {
+	var input string
+	fmt.Printf("Enter file path (%s): ", fileFormatsHint)
+	fmt.Scanln(&input)
+	path := strings.TrimSpace(input)
+
+	if path == "" {
+		fmt.Println("No file path provided")
+		return
+	}
+
+	if _, err := os.Stat(path); os.IsNotExist(err) {
+		fmt.Printf("File '%s' does not exist\n", path)
+		return
+	}
+
+	newGraph := c.readGraphFile(path)
+	if newGraph != nil {
+		// Replace the current graph with the loaded one
+		entry := c.workspace.ActiveGraph()
+		entry.Graph, entry.Source = newGraph, path
+		fmt.Printf("Graph successfully loaded from %s\n", path)
+	} else {
+		fmt.Printf("Failed to load graph from %s\n", path)
+	}
+}
+
+// readCSVEdgeList asks how the columns of the CSV edge list at path map to the edges and reads it.
+// The rows that were rejected are printed and can be saved to a file
+func (c *CLI) readCSVEdgeList(path string) *graph.GraphInfo {
+	// Column names and lists of them can have spaces, so the whole line is read
+	ask := func(prompt string) string {
+		fmt.Print(prompt)
+		return readLine()
+	}
+	yes := func(prompt string) bool {
+		return strings.ToLower(ask(prompt)) == "y"
+	}
+
+	if file, err := os.Open(path); err == nil {
+		firstLine, _ := bufio.NewReader(file).ReadString('\n')
+		file.Close()
+		fmt.Printf("The first row: %s\n", strings.TrimSpace(firstLine))
+	}
+
+	options := graph.CSVEdgeListOptions{}
+	switch delimiter := ask("Delimiter (Enter for ',', 'tab' for tabs): "); delimiter {
+	case "":
+	case "tab", "\\t":
+		options.Delimiter = '\t'
+	default:
+		options.Delimiter, _ = utf8.DecodeRuneInString(delimiter)
+	}
+	options.Header = yes("Is the first row a header? (y/n): ")
+	columnHint := "name or number starting with 1"
+	if !options.Header {
+		columnHint = "number starting with 1"
+	}
+	options.From = ask(fmt.Sprintf("Column of the start of the edges (%s): ", columnHint))
+	options.To = ask(fmt.Sprintf("Column of the end of the edges (%s): ", columnHint))
+	options.Weight = ask("Column of the weight (Enter for an unweighted graph): ")
+	if attributes := ask("Columns of the attributes, separated by commas (* - all the others, Enter - none): "); attributes != "" {
+		options.Attributes = strings.Split(attributes, ",")
+	}
+	options.Oriented = yes("Is the graph oriented? (y/n): ")
+	options.Multigraph = yes("Allow parallel edges (multigraph)? (y/n): ")
+
+	g, rejected, err := graph.ReadCSVEdgeList(path, options)
+	if err != nil {
+		fmt.Println(err.Error())
+		return nil
+	}
+	fmt.Printf("Graph loaded from %s: %d vertices, %d edges\n", path, len(g.Nodes()), c.countEdges(g))
+	if len(rejected) == 0 {
+		return g
+	}
+
+	const shown = 10
+	fmt.Printf("\033[31m%d rows were rejected\033[0m:\n", len(rejected))
+	for i, row := range rejected {
+		if i == shown {
+			fmt.Printf("  ... and %d more\n", len(rejected)-shown)
+			break
+		}
+		fmt.Printf("  %v\n", row)
+	}
+	if report := ask("Save the list of the rejected rows? Enter file path (or press Enter to skip): "); report != "" {
+		lines := make([]string, 0, len(rejected))
+		for _, row := range rejected {
+			lines = append(lines, row.Error())
+		}
+		if err := os.WriteFile(report, []byte(strings.Join(lines, "\n")+"\n"), 0644); err != nil {
+			fmt.Printf("Error saving file: %v\n", err)
+		}
+	}
+	return g
+}
+
+func (c *CLI) saveToFile(g *graph.GraphInfo) {
+	var input string
+	fmt.Printf("Enter file path (%s): ", fileFormatsHint)
+	fmt.Scanln(&input)
+	path := strings.TrimSpace(input)
+
+	if path == "" {
+		fmt.Println("No file path provided")
+		return
+	}
+
+	err := graph.WriteFile(g, path, "")
+	if err != nil {
+		fmt.Printf("Error saving file: %v\n", err)
+	} else {
+		c.workspace.ActiveGraph().Source = path
+		c.printSaved(g, path)
+	}
+}
+
+func (c *CLI) listKnots(g *graph.GraphInfo) {
+	fmt.Println("\nVertices with loops (knots):")
+
+	if !g.IsOriented() {
+		fmt.Println("This operation only makes sense for directed graphs")
+		return
+	}
+
+	knots := graph.Knots(g)
+
+	if len(knots) == 0 {
+		fmt.Println("No vertices with loops found")
+		return
+	}
+
+	for i, node := range knots {
+		fmt.Printf("%d. Vertex '%v' has self-loop(s)\n", i+1, node.Value)
+	}
+
+	c.exportHighlighted(g, &graph.Diagram{Classes: map[string][]*graph.Node{"knot": knots}}, "knots")
+}
+
+func (c *CLI) task3(g *graph.GraphInfo) {
+	if len(g.Nodes()) == 0 {
+		fmt.Println("No vertices to examine")
+		return
+	}
+
+	c.listVertices(g)
+
+	node := c.readVertex(g, "Enter main vertex id: ")
+	if node == nil {
+		fmt.Println("Invalid vertex id")
+		return
+	}
+
+	nodes := graph.VerticesWithLesserInDegree(g, node)
+
+	if len(nodes) == 0 {
+		fmt.Println("No vertices with half-degree of entrance lesser than that of given Vertex")
+		return
+	} else {
+		fmt.Println("\nVertices which half-degree of entrance is lesser than that of given Vertex:")
+	}
+
+	for i, n := range nodes {
+		fmt.Printf("%d. Vertex '%v' has less half-degree of entrance than that of '%v'\n", i+1, n.Value, node.Value)
+	}
+}
+
+func (c *CLI) task4(g *graph.GraphInfo) {
+	var nodes = graph.IsolatedVertices(g)
+
+	if len(nodes) == 0 {
+		fmt.Printf("No vertices to remove\n")
+	}
+
+	for _, node := range nodes {
+		fmt.Printf("Removing vertex '%v'\n", node.Value)
+		graph.RemoveVertex(g, node)
+	}
+}
+
+func (c *CLI) adjacencyList(g *graph.GraphInfo) {
+	var input string
+	fmt.Print("View as (l)ist, (a)djacency matrix or (i)ncidence matrix? [l]: ")
+	fmt.Scanln(&input)
+	switch strings.ToLower(strings.TrimSpace(input)) {
+	case "a":
+		// A missing edge of a weighted graph is INF, so that it differs from the weight 0
+		options := graph.MatrixOptions{Header: true}
+		if g.IsWeighted() {
+			options.NoEdge = graph.NoEdgeInf
+		}
+		c.printMatrix(g, "Adjacency Matrix", graph.EncodeAdjacencyMatrix, options)
+		return
+	case "i":
+		c.printMatrix(g, "Incidence Matrix", graph.EncodeIncidenceMatrix, graph.MatrixOptions{Header: true})
+		return
+	}
+
+	fmt.Println("\nAdjacency List:")
+
+	if len(g.Nodes()) == 0 {
+		fmt.Println("No edges")
+		return
+	}
+
+	for _, nd := range g.Nodes() {
+		fmt.Printf("%v: ", nd.Value)
+		for _, v := range g.OutEdges(nd) {
+			fmt.Printf("%v ", v.Other(nd).Value)
+		}
+		print("\n")
+	}
+}
+
+// printMatrix prints the graph as a matrix with the vertices in the order they were added
+func (c *CLI) printMatrix(g *graph.GraphInfo, title string, encode func(*graph.GraphInfo, io.Writer, graph.MatrixOptions) error, options graph.MatrixOptions) {
+	fmt.Printf("\n%s:\n", title)
+
+	if len(g.Nodes()) == 0 {
+		fmt.Println("No vertices")
+		return
+	}
+
+	if err := encode(g, os.Stdout, options); err != nil {
+		fmt.Printf("Can not show the matrix: %v\n", err)
+	}
+}
+
+func (c *CLI) task5(g *graph.GraphInfo) {
+	fmt.Println("\n=== Cyclomatic Number Calculation ===")
+
+	if len(g.Nodes()) == 0 {
+		fmt.Println("Graph is empty")
+		return
+	}
+
+	cyclomaticNumber := graph.CyclomaticNumber(g)
+
+	fmt.Printf("Cyclomatic number (cycle rank): %d\n", cyclomaticNumber)
+	fmt.Printf("This is the minimum number of edges to remove to make the graph acyclic\n")
+
+	// Additional information
+	e := graph.CountEdges(g)
+	v := len(g.Nodes())
+	p := graph.CountConnectedComponents(g)
+
+	fmt.Printf("\nCalculation details:\n")
+	fmt.Printf("Number of edges (e): %d\n", e)
+	fmt.Printf("Number of vertices (v): %d\n", v)
+	fmt.Printf("Number of connected components (p): %d\n", p)
+	fmt.Printf("Formula: mu = e - v + p = %d - %d + %d = %d\n", e, v, p, cyclomaticNumber)
+
+	if cyclomaticNumber == 0 {
+		fmt.Println("\nThe graph is already acyclic!")
+	} else {
+		fmt.Printf("\nYou need to remove at least %d edge(s) to make the graph acyclic\n", cyclomaticNumber)
+	}
+}
+
+func (c *CLI) findCommonVertexWithEqualPaths(g *graph.GraphInfo) {
+	fmt.Println("\n=== Find Vertex with Equal Path Lengths ===")
+
+	if len(g.Nodes()) < 3 {
+		fmt.Println("Need at least 3 vertices for this operation")
+		return
+	}
+
+	c.listVertices(g)
+
+	// Get vertex u
+	u := c.readVertex(g, "Enter id of vertex u: ")
+	if u == nil {
+		fmt.Println("Invalid vertex id")
+		return
+	}
+
+	// Get vertex v
+	v := c.readVertex(g, "Enter id of vertex v: ")
+	if v == nil {
+		fmt.Println("Invalid vertex id")
+		return
+	}
+
+	if u == v {
+		fmt.Println("Vertices u and v must be different")
+		return
+	}
+
+	fmt.Printf("\nSearching for vertex reachable from both '%v' and '%v' with equal path length...\n", u.Value, v.Value)
+
+	// Try with shortest paths first (more efficient)
+	target, length := graph.FindCommonVertexWithEqualPathLength(g, u, v)
+
+	if target == nil {
+		// If no result with shortest paths, try with all possible paths
+		fmt.Println("No vertex found with shortest paths of equal length. Trying all possible paths...")
+		target, length = graph.FindCommonVertexWithEqualPathLengthAllPaths(g, u, v)
+	}
+
+	if target != nil {
+		fmt.Printf("Found vertex: '%v'\n", target.Value)
+		fmt.Printf("Path length from '%v': %d edges\n", u.Value, length)
+		fmt.Printf("Path length from '%v': %d edges\n", v.Value, length)
+		fmt.Printf("Total path length: %d edges from each starting vertex\n", length)
+	} else {
+		fmt.Println("No vertex found that is reachable from both u and v with paths of equal length")
+
+		// Provide some diagnostic information
+		distancesFromU := graph.BfsWithDistances(g, u)
+		distancesFromV := graph.BfsWithDistances(g, v)
+
+		fmt.Println("\nDiagnostic info:")
+		fmt.Printf("Vertices reachable from '%v': %d\n", u.Value, len(distancesFromU))
+		fmt.Printf("Vertices reachable from '%v': %d\n", v.Value, len(distancesFromV))
+
+		// Find common reachable vertices (regardless of path length)
+		commonVertices := 0
+		for vertex := range distancesFromU {
+			if _, exists := distancesFromV[vertex]; exists {
+				commonVertices++
+			}
+		}
+		fmt.Printf("Common reachable vertices: %d\n", commonVertices)
+	}
+}
+
+// CLI wrapper for Prim's algorithm
+func (c *CLI) findMinimumSpanningTreePrim(g *graph.GraphInfo) {
+	fmt.Println("\n=== Prim's Algorithm - Minimum Spanning Tree ===")
+
+	if g.IsOriented() {
+		fmt.Println("Prim's algorithm only works for undirected graphs")
+		return
+	}
+
+	if len(g.Nodes()) == 0 {
+		fmt.Println("Graph is empty")
+		return
+	}
+
+	// Let user choose start vertex or use automatic selection
+	c.listVertices(g)
+
+	var input string
+	fmt.Print("Enter starting vertex id (or press Enter for automatic): ")
+	fmt.Scanln(&input)
+
+	var result *graph.PrimResult
+	if strings.TrimSpace(input) == "" {
+		// Automatic selection - try all starts and pick best
+		fmt.Println("Using automatic start vertex selection...")
+		result = graph.PrimAllStarts(g)
+	} else {
+		// User specified start vertex
+		start := c.parseVertex(g, input)
+		if start == nil {
+			fmt.Println("Invalid vertex id, using automatic selection")
+			result = graph.PrimAllStarts(g)
+		} else {
+			fmt.Printf("Starting from vertex: %v\n", start.Value)
+			result = graph.Prim(g, start)
+		}
+	}
+
+	// Display results
+	if !result.IsConnected {
+		fmt.Println("Graph is not connected. Cannot form a spanning tree.")
+		fmt.Printf("MST edges found for connected component: %d\n", len(result.MSTEdges))
+		fmt.Printf("Total weight: %.2f\n", result.TotalWeight)
+	} else {
+		fmt.Println("Minimum Spanning Tree found!")
+		fmt.Printf("Total weight: %.2f\n", result.TotalWeight)
+		fmt.Printf("Number of edges in MST: %d\n", len(result.MSTEdges))
+	}
+
+	// Display MST edges
+	fmt.Println("\nEdges in Minimum Spanning Tree:")
+	if len(result.MSTEdges) == 0 {
+		fmt.Println("No edges in MST")
+		return
+	}
+
+	for i, edge := range result.MSTEdges {
+		fmt.Printf("%d. %v -- %v", i+1, edge.List[0].Value, edge.List[1].Value)
+		if g.IsWeighted() {
+			fmt.Printf(" (weight: %.2f)", edge.Weight)
+		}
+		fmt.Println()
+	}
+
+	// Display original graph info for comparison
+	originalEdges := graph.CountEdges(g)
+	fmt.Printf("\nOriginal graph: %d edges\n", originalEdges)
+	fmt.Printf("MST reduction: %d edges removed\n", originalEdges-len(result.MSTEdges))
+
+	c.exportHighlighted(g, mstDiagram(result), "MST")
+	c.exportJSON("MST", result.MarshalJSON)
+}
+
+// exportHighlighted offers to save the graph with the result of an algorithm drawn on it: to an SVG image,
+// a CeTZ (.typ), TikZ (.tikz, .tex), Mermaid (.mmd, .mermaid) or PlantUML (.puml, .plantuml) drawing
+// or a DOT file with the first set of highlighted edges
+func (c *CLI) exportHighlighted(g *graph.GraphInfo, diagram *graph.Diagram, what string) {
+	var input string
+	fmt.Printf("\nSave the graph with the %s drawn? Enter file path (.svg - SVG, .typ - CeTZ, .tikz/.tex - TikZ, "+
+		".mmd - Mermaid, .puml - PlantUML, other - DOT) or press Enter to skip: ", what)
+	fmt.Scanln(&input)
+	path := strings.TrimSpace(input)
+	if path == "" {
+		return
+	}
+
+	var err error
+	switch strings.ToLower(filepath.Ext(path)) {
+	case ".svg":
+		err = graph.WriteSVG(g, path, diagram)
+	case ".typ":
+		err = graph.WriteCeTZ(g, path, diagram)
+	case ".tikz", ".tex":
+		err = graph.WriteTikZ(g, path, diagram)
+	case ".mmd", ".mermaid":
+		err = graph.WriteMermaid(g, path, diagram)
+	case ".puml", ".plantuml":
+		err = graph.WritePlantUML(g, path, diagram)
+	default:
+		var highlight *graph.Highlight
+		if len(diagram.Highlights) > 0 {
+			highlight = diagram.Highlights[0]
+		}
+		err = graph.WriteDOT(g, path, highlight)
+	}
+	if err != nil {
+		fmt.Printf("Error saving file: %v\n", err)
+		return
+	}
+	c.printSaved(g, path)
+}
+
+// renderSVG renders the graph to an SVG image with the chosen layout, optionally with the result
+// of Prim, Bellman-Ford or Edmonds-Karp drawn on it
+func (c *CLI) renderSVG(g *graph.GraphInfo) {
+	fmt.Println("\n=== Render to SVG ===")
+
+	var input string
+	fmt.Print("Layout: (a)uto, (f)orce-directed, (c)ircular or (l)ayered [a]: ")
+	fmt.Scanln(&input)
+	var layout graph.Layout
+	switch strings.ToLower(strings.TrimSpace(input)) {
+	case "", "a":
+		layout = graph.AutoLayout(g)
+	case "f":
+		layout = graph.ForceDirectedLayout(g)
+	case "c":
+		layout = graph.CircularLayout(g)
+	case "l":
+		layout = graph.LayeredLayout(g)
+	default:
+		fmt.Println("Invalid layout")
+		return
+	}
+
+	input = ""
+	fmt.Print("Draw a result: (n)one, (p)rim MST, (b)ellman-Ford shortest paths or (m)ax flow [n]: ")
+	fmt.Scanln(&input)
+	diagram := &graph.Diagram{}
+	switch strings.ToLower(strings.TrimSpace(input)) {
+	case "", "n":
+	case "p":
+		if g.IsOriented() {
+			fmt.Println("Prim's algorithm only works for undirected graphs")
+			return
+		}
+		diagram = mstDiagram(graph.PrimAllStarts(g))
+	case "b":
+		c.listVertices(g)
+		start := c.readVertex(g, "Enter starting vertex id: ")
+		if start == nil {
+			fmt.Println("Invalid vertex id")
+			return
+		}
+		diagram = shortestPathsDiagram(graph.BellmanFord(g, start))
+	case "m":
+		source, sink := c.readFlowTerminals(g)
+		if source == nil {
+			return
+		}
+		result := graph.EdmondsKarp(c.flowNetwork(g), source, sink)
+		fmt.Printf("Max Flow Value: %.2f\n", result.MaxFlowValue)
+		diagram = maxFlowDiagram(g, result)
+	default:
+		fmt.Println("Invalid option")
+		return
+	}
+	diagram.Layout = layout
+
+	input = ""
+	fmt.Print("Enter SVG file path: ")
+	fmt.Scanln(&input)
+	path := strings.TrimSpace(input)
+	if path == "" {
+		fmt.Println("No file path provided")
+		return
+	}
+	if err := graph.WriteSVG(g, path, diagram); err != nil {
+		fmt.Printf("Error saving file: %v\n", err)
+		return
+	}
+	c.printSaved(g, path)
+}
+
+// mstDiagram draws the minimum spanning tree found by Prim
+func mstDiagram(result *graph.PrimResult) *graph.Diagram {
+	return &graph.Diagram{Highlights: []*graph.Highlight{graph.HighlightMST(result)}}
+}
+
+// shortestPathsDiagram draws the shortest paths found by Bellman-Ford and the distances to the vertices
+func shortestPathsDiagram(result *graph.BellmanFordResult) *graph.Diagram {
+	return &graph.Diagram{
+		Highlights:  []*graph.Highlight{graph.HighlightShortestPaths(result)},
+		VertexNotes: graph.DistanceNotes(result),
+	}
+}
+
+// maxFlowDiagram draws the min cut (red) and the edges with flow (blue) found by Edmonds-Karp,
+// the edges are labeled "flow/capacity"
+func maxFlowDiagram(g *graph.GraphInfo, result *graph.MaxFlowResult) *graph.Diagram {
+	return &graph.Diagram{
+		Highlights: []*graph.Highlight{graph.HighlightMinCut(result), graph.HighlightFlow(result)},
+		EdgeLabels: graph.FlowLabels(g, result),
+	}
+}
+
+// exportJSON offers to save the result of an algorithm to a JSON file
+func (c *CLI) exportJSON(what string, marshal func() ([]byte, error)) {
+	var input string
+	fmt.Printf("\nSave the %s to a JSON file? Enter file path (or press Enter to skip): ", what)
+	fmt.Scanln(&input)
+	path := strings.TrimSpace(input)
+	if path == "" {
+		return
+	}
+
+	data, err := marshal()
+	if err != nil {
+		fmt.Printf("Error encoding the %s: %v\n", what, err)
+		return
+	}
+	var indented bytes.Buffer
+	if err := json.Indent(&indented, data, "", "  "); err != nil {
+		fmt.Printf("Error encoding the %s: %v\n", what, err)
+		return
+	}
+	indented.WriteString("\n")
+	if err := os.WriteFile(path, indented.Bytes(), 0644); err != nil {
+		fmt.Printf("Error saving file: %v\n", err)
+		return
+	}
+	fmt.Printf("The %s saved to %s\n", what, path)
+}
+
+// CLI wrapper для поиска вершин в пределах расстояния
+func (c *CLI) findVerticesWithinDistance(g *graph.GraphInfo) {
+	fmt.Println("\n=== Search Within Distance N ===")
+
+	if !g.IsOriented() {
+		fmt.Println("This operation is intended for use upon oriented graphs only")
+		return
+	}
+
+	if len(g.Nodes()) == 0 {
+		fmt.Println("The graph is empty")
+		return
+	}
+
+	// Показываем список вершин
+	c.listVertices(g)
+
+	// Выбор стартовой вершины
+	startVertex := c.readVertex(g, "Enter the start vertex id: ")
+	if startVertex == nil {
+		fmt.Println("Invalid vertex id!")
+		return
+	}
+
+	// Ввод максимального расстояния
+	var input string
+	fmt.Print("Enter N: ")
+	fmt.Scanln(&input)
+
+	maxDist, err := strconv.ParseFloat(input, 64)
+	if err != nil || maxDist < 0 {
+		fmt.Println("Invalid distance value!")
+		return
+	}
+
+	// Выполняем поиск
+	vertices := graph.FindVerticesWithinDistance(g, startVertex, maxDist)
+
+	// Выводим результаты
+	fmt.Printf("\nSearch Results:\n")
+	fmt.Printf("Starting vertex: '%v'\n", startVertex.Value)
+	fmt.Printf("N: %f\n", maxDist)
+
+	if len(vertices) == 0 {
+		fmt.Println("No vertices within the given distance")
+		return
+	}
+
+	fmt.Printf("Number of vertices found: %d\n", len(vertices))
+	fmt.Println("Vertices within distance N:")
+
+	for i, vertex := range vertices {
+		fmt.Printf("%d. '%v'\n", i+1, vertex.Value)
+	}
+
+	c.exportHighlighted(g, &graph.Diagram{Classes: map[string][]*graph.Node{
+		"start":  {startVertex},
+		"within": vertices,
+	}}, "vertices found")
+}
+
+// CLI wrapper для упрощённой версии алгоритма Флойда-Уоршелла
+func (c *CLI) findAllPairsShortestPathsSimple(g *graph.GraphInfo) {
+	fmt.Println("\n=== Floyd-Warshall algorithm - Minimal Distances Between Each Pair of Vertices ===")
+
+	if len(g.Nodes()) == 0 {
+		fmt.Println("The graph is empty")
+		return
+	}
+
+	distances, hasNegativeCycle := graph.FloydWarshallSimple(g)
+
+	if hasNegativeCycle {
+		fmt.Println("WARNING! The graph contains a negative cycle!")
+		fmt.Println("The results may be incorrect")
+		return
+	}
+
+	// Вывод матрицы расстояний
+	fmt.Println()
+	printDistanceMatrix(os.Stdout, g.Nodes(), distances)
+
+	c.exportJSON("distances", func() ([]byte, error) {
+		return graph.DistancesJSON(g.Nodes(), distances, hasNegativeCycle)
+	})
+}
+
+// printDistanceMatrix выводит матрицу расстояний в простом формате
+func printDistanceMatrix(w io.Writer, nodes []*graph.Node, dist map[*graph.Node]map[*graph.Node]float64) {
+	fmt.Fprintln(w, "Shortest Paths Matrix:")
+	fmt.Fprint(w, "     ")
+	for _, node := range nodes {
+		fmt.Fprintf(w, "%5v", fmt.Sprintf("%v", node.Value))
+	}
+	fmt.Fprintln(w)
+
+	for _, u := range nodes {
+		fmt.Fprintf(w, "%5v", fmt.Sprintf("%v", u.Value))
+		for _, v := range nodes {
+			d := dist[u][v]
+			if math.IsInf(d, 1) {
+				fmt.Fprintf(w, "%5s", "INF")
+			} else {
+				fmt.Fprintf(w, "%5.1f", d)
+			}
+		}
+		fmt.Fprintln(w)
+	}
+}
+
+// CLI wrapper для алгоритма Беллмана-Форда
+func (c *CLI) findShortestPathsFromVertex(g *graph.GraphInfo) {
+	fmt.Println("\n=== Bellman-Ford algorithm - Shortest Paths From Vertex U ===")
+
+	if len(g.Nodes()) == 0 {
+		fmt.Println("The graph is empty")
+		return
+	}
+
+	// Показываем список вершин
+	c.listVertices(g)
+
+	startVertex := c.readVertex(g, "Enter starting vertex id: ")
+	if startVertex == nil {
+		fmt.Println("Invalid vertex id")
+		return
+	}
+
+	result := graph.BellmanFord(g, startVertex)
+
+	// Вывод результатов
+	c.printBellmanFordResults(g, startVertex, result)
+
+	c.exportHighlighted(g, shortestPathsDiagram(result), "shortest paths")
+	c.exportJSON("shortest paths", result.MarshalJSON)
+}
+
+// printBellmanFordResults выводит результаты алгоритма Беллмана-Форда
+func (c *CLI) printBellmanFordResults(g *graph.GraphInfo, start *graph.Node, result *graph.BellmanFordResult) {
+	fmt.Printf("\nResults for starting vertex '%v':\n", start.Value)
+
+	if result.HasNegativeCycle {
+		fmt.Println("WARNING! The graph contains a negative cycle!")
+		fmt.Println("Some vertices can have infinetily large distance (-INF)")
+	}
+
+	fmt.Println("\nShortest distances to the vertex:")
+	for _, node := range g.Nodes() {
+		if node == start {
+			continue // Пропускаем стартовую вершину
+		}
+
+		distance := result.Distances[node]
+		fmt.Printf("  From '%v': ", node.Value)
+
+		if math.IsInf(distance, -1) {
+			fmt.Println("-INF (Reachable through a negative cycle)")
+		} else if math.IsInf(distance, 1) {
+			fmt.Println("INF (Unreachable)")
+		} else {
+			fmt.Printf("%.2f", distance)
+
+			// Показываем путь, если он существует
+			if path := result.ReconstructPath(node); path != nil {
+				fmt.Printf(" | PATH: ")
+				for i, pathNode := range path {
+					if i > 0 {
+						fmt.Print(" -> ")
+					}
+					fmt.Printf("'%v'", pathNode.Value)
+				}
+			}
+			fmt.Println()
+		}
+	}
+}
+
+// CLI wrapper для алгоритма Эдмондса-Карпа
+func (c *CLI) findMaxFlowEdmondsKarp(g *graph.GraphInfo) {
+	fmt.Println("\n=== Edmond-Karp algorithm - Max Flow ===")
+
+	if len(g.Nodes()) < 2 {
+		fmt.Println("Needed at least 2 vertices")
+		return
+	}
+
+	// Проверяем, что граф может быть потоковой сетью
+	if !g.IsWeighted() {
+		fmt.Println("\033[31mWarning\033[0m: The graph is unweighed, used capacity equals to 1")
+	}
+
+	source, sink := c.readFlowTerminals(g)
+	if source == nil {
+		return
+	}
+	// Создаём потоковую сеть
+	network := c.flowNetwork(g)
+
+	// Запускаем алгоритм Эдмондса-Карпа
+	result := graph.EdmondsKarp(network, source, sink)
+
+	// Выводим результаты
+	c.printMaxFlowResults(network, result)
+
+	c.exportHighlighted(g, maxFlowDiagram(g, result), "max flow")
+	c.exportJSON("max flow", result.MarshalJSON)
+}
+
+// readFlowTerminals возвращает исток и сток: заданные в графе (например, в файле DIMACS) используются без запроса,
+// иначе они запрашиваются у пользователя. Возвращает nil, nil, если вершины не выбраны
+func (c *CLI) readFlowTerminals(g *graph.GraphInfo) (*graph.Node, *graph.Node) {
+	source, sink := graph.FlowTerminals(g)
+	if source != nil && sink != nil {
+		fmt.Printf("Using the source '%v' and the sink '%v' set in the graph\n", source.Value, sink.Value)
+		return source, sink
+	}
+
+	// Показываем список вершин
+	c.listVertices(g)
+
+	// Выбор истока (source)
+	source = c.readVertex(g, "Enter source vertex id: ")
+	if source == nil {
+		fmt.Println("\033[31mInvalid vertex id\033[0m")
+		return nil, nil
+	}
+
+	// Выбор стока (sink)
+	sink = c.readVertex(g, "Enter sink vertex id: ")
+	if sink == nil {
+		fmt.Println("\033[31mInvalid vertex id\033[0m")
+		return nil, nil
+	}
+
+	if source == sink {
+		fmt.Println("\033[31mThe source and the sink must not be the same vertex\033[0m")
+		return nil, nil
+	}
+	return source, sink
+}
+
+// flowNetwork создаёт потоковую сеть графа и предупреждает о пропущенных рёбрах
+func (c *CLI) flowNetwork(g *graph.GraphInfo) *graph.FlowNetwork {
+	network, skipped := graph.CreateFlowNetwork(g)
+	for _, edge := range skipped {
+		fmt.Printf("\033[31mWarning\033[0m: edge from %v to %v has negative capacity %.2f, skipped\n",
+			edge.List[0].Value, edge.List[1].Value, edge.Weight)
+	}
+	return network
+}
+
+// printMaxFlowResults выводит результаты поиска максимального потока
+func (c *CLI) printMaxFlowResults(network *graph.FlowNetwork, result *graph.MaxFlowResult) {
+	fmt.Printf("\n=== Max Flow Search Results ===\n")
+	fmt.Printf("Source: '%v'\n", result.Source.Value)
+	fmt.Printf("Sink: '%v'\n", result.Sink.Value)
+	fmt.Printf("Max Flow Value: %.2f\n", result.MaxFlowValue)
+
+	// Выводим поток по каждому ребру
+	fmt.Println("\nEdge flow distribution:")
+	hasFlow := false
+	for _, edge := range network.Edges {
+		flow := result.Flow[edge]
+		if flow > 0 {
+			hasFlow = true
+			fmt.Printf("  From %v to %v: %.2f / %.2f\n",
+				edge.From.Value, edge.To.Value, flow, edge.Capacity)
+		}
+	}
+
+	if !hasFlow {
+		fmt.Println("  No zero flows")
+	}
+
+	totalCapacity := 0.0
+	// Выводим минимальный разрез
+	if len(result.MinCut) > 0 {
+		fmt.Println("\nMinimal cut of an edge:")
+		for _, edge := range result.MinCut {
+			fmt.Printf("  from %v to %v (capacity: %.2f)\n",
+				edge.From.Value, edge.To.Value, edge.Capacity)
+			totalCapacity += edge.Capacity
+		}
+		fmt.Printf("Cut's total capacity: %.2f\n", totalCapacity)
+	}
+
+	// Проверка корректности (величина потока = capacity разреза)
+	if len(result.MinCut) > 0 {
+		fmt.Printf("Probe: Flow value is equal to minimal cut's capacity (%.2f = %.2f)\n",
+			result.MaxFlowValue, totalCapacity)
+	}
+	/*
+		// Статистика
+		fmt.Printf("\nСтатистика:\n")
+		fmt.Printf("Всего вершин в сети: %d\n", len(network.Nodes))
+		fmt.Printf("Всего рёбер в сети: %d\n", len(network.Edges))
+		fmt.Printf("Рёбер в минимальном разрезе: %d\n", len(result.MinCut))
+	*/
+}
+
+// readLine reads a whole line from the standard input, unlike fmt.Scanln which stops at a space.
+// Reads byte by byte, so nothing after the line is buffered away from fmt.Scanln
+func readLine() string {
+	var line strings.Builder
+	b := make([]byte, 1)
+	for {
+		n, err := os.Stdin.Read(b)
+		if n == 0 || err != nil || b[0] == '\n' {
+			break
+		}
+		line.WriteByte(b[0])
+	}
+	return strings.TrimSpace(line.String())
+}
+
+// readAttributes asks whether to work with a vertex, an edge or the metadata of the graph itself.
+// Returns the attributes of the chosen element and its description, or nil if the input is invalid
+func (c *CLI) readAttributes(g *graph.GraphInfo) (*graph.Attributes, string) {
+	var input string
+	fmt.Print("Vertex, edge or graph? (v/e/g): ")
+	fmt.Scanln(&input)
+
+	switch strings.ToLower(strings.TrimSpace(input)) {
+	case "g":
+		entry := c.workspace.ActiveGraph()
+		return &entry.Metadata, fmt.Sprintf("graph '%s'", entry.Name)
+	case "v":
+		c.listVertices(g)
+		node := c.readVertex(g, "Enter vertex id: ")
+		if node == nil {
+			fmt.Println("Invalid vertex id")
+			return nil, ""
+		}
+		return &node.Attributes, fmt.Sprintf("vertex '%v'", node.Value)
+	case "e":
+		if c.listEdges(g, true) != nil {
+			return nil, ""
+		}
+		fmt.Print("Enter edge id: ")
+		fmt.Scanln(&input)
+		id, err := strconv.ParseUint(strings.TrimSpace(input), 10, 32)
+		edge := g.EdgeById(uint32(id))
+		if err != nil || edge == nil {
+			fmt.Println("Invalid edge id")
+			return nil, ""
+		}
+		return &edge.Attributes, fmt.Sprintf("edge from '%v' to '%v'", edge.List[0].Value, edge.List[1].Value)
+	default:
+		fmt.Println("Invalid input")
+		return nil, ""
+	}
+}
+
+func (c *CLI) setAttribute(g *graph.GraphInfo) {
+	attributes, name := c.readAttributes(g)
+	if attributes == nil {
+		return
+	}
+
+	var key string
+	fmt.Print("Enter attribute name: ")
+	fmt.Scanln(&key)
+	key = strings.TrimSpace(key)
+	if key == "" {
+		fmt.Println("No attribute name provided")
+		return
+	}
+
+	// The value may contain spaces, so the whole line is read
+	fmt.Print("Enter attribute value: ")
+	value := readLine()
+
+	attributes.Set(key, value)
+	fmt.Printf("Attribute '%s' of %s set to '%s'\n", key, name, value)
+}
+
+func (c *CLI) getAttribute(g *graph.GraphInfo) {
+	attributes, name := c.readAttributes(g)
+	if attributes == nil {
+		return
+	}
+
+	var key string
+	fmt.Print("Enter attribute name: ")
+	fmt.Scanln(&key)
+	key = strings.TrimSpace(key)
+
+	value, ok := attributes.Get(key)
+	if !ok {
+		fmt.Printf("%s has no attribute '%s'\n", name, key)
+		return
+	}
+	fmt.Printf("%s = '%s'\n", key, value)
+}
+
+func (c *CLI) listAttributes(g *graph.GraphInfo) {
+	attributes, name := c.readAttributes(g)
+	if attributes == nil {
+		return
+	}
+
+	fmt.Printf("\nAttributes of %s:\n", name)
+	if len(*attributes) == 0 {
+		fmt.Println("No attributes")
+		return
+	}
+	for _, key := range attributes.Keys() {
+		fmt.Printf("%s = '%s'\n", key, (*attributes)[key])
+	}
+}
+
+// renameGraph asks for a graph and its new name
+func (c *CLI) renameGraph() {
+	graphs := c.workspace.Graphs
+	if len(graphs) == 0 {
+		fmt.Println("No graphs available. Please add a graph first.")
+		return
+	}
+
+	fmt.Println("\n=== Rename Graph ===")
+	for i, entry := range graphs {
+		fmt.Printf("%d. %s\n", i+1, entry.Name)
+	}
+	var input string
+	fmt.Print("Choose a graph: ")
+	fmt.Scanln(&input)
+	choice, err := strconv.Atoi(strings.TrimSpace(input))
+	if err != nil || choice < 1 || choice > len(graphs) {
+		fmt.Println("Invalid graph selection.")
+		return
+	}
+	entry := graphs[choice-1]
+
+	fmt.Print("Enter new name: ")
+	name := readLine()
+	if name == "" {
+		fmt.Println("No name provided")
+		return
+	}
+	if other := c.workspace.ByName(name); other != nil && other != entry {
+		fmt.Printf("There is already a graph named '%s'\n", name)
+		return
+	}
+	fmt.Printf("Graph '%s' renamed to '%s'\n", entry.Name, name)
+	entry.Name = name
+}
+
+// workspaceState returns the workspace as it is written to a file, to compare it with the saved one
+func (c *CLI) workspaceState() []byte {
+	var buffer bytes.Buffer
+	if err := graph.EncodeWorkspace(c.workspace, &buffer); err != nil {
+		return nil
+	}
+	return buffer.Bytes()
+}
+
+// unsaved reports whether the workspace has changed since it was saved or opened
+func (c *CLI) unsaved() bool {
+	if c.savedState == nil {
+		return len(c.workspace.Graphs) > 0
+	}
+	return !bytes.Equal(c.workspaceState(), c.savedState)
+}
+
+// confirmDiscard asks whether the unsaved changes of the workspace may be lost.
+// Returns true if there are none
+func (c *CLI) confirmDiscard() bool {
+	if !c.unsaved() {
+		return true
+	}
+	var input string
+	fmt.Printf("Warning: the changes of the %d graph(s) of the workspace are not saved.\n", len(c.workspace.Graphs))
+	fmt.Print("Are you sure? (y/n): ")
+	fmt.Scanln(&input)
+	return strings.ToLower(strings.TrimSpace(input)) == "y"
+}
+
+// openWorkspace replaces the graphs of the session with the ones of a workspace file
+// and goes to its active graph
+func (c *CLI) openWorkspace() {
+	var input string
+	fmt.Print("Enter workspace file path: ")
+	fmt.Scanln(&input)
+	path := strings.TrimSpace(input)
+	if path == "" {
+		fmt.Println("No file path provided")
+		return
+	}
+	if !c.confirmDiscard() {
+		return
+	}
+
+	workspace, err := graph.ReadWorkspace(path)
+	if err != nil {
+		fmt.Println(err.Error())
+		return
+	}
+	c.workspace, c.workspacePath = workspace, path
+	c.savedState = c.workspaceState()
+	fmt.Printf("Workspace loaded from %s: %d graph(s), saved %s\n",
+		path, len(workspace.Graphs), workspace.Saved.Local().Format("2006-01-02 15:04"))
+
+	if active := workspace.ActiveGraph(); active != nil {
+		fmt.Printf("Active graph: %s\n", active.Name)
+		c.graphOperationsMenu()
+	}
+}
+
+// saveWorkspace saves all the graphs of the session to a workspace file,
+// by default to the one it was opened from or saved to
+func (c *CLI) saveWorkspace() {
+	var input string
+	if c.workspacePath != "" {
+		fmt.Printf("Enter workspace file path (Enter - %s): ", c.workspacePath)
+	} else {
+		fmt.Print("Enter workspace file path (.json): ")
+	}
+	fmt.Scanln(&input)
+	path := strings.TrimSpace(input)
+	if path == "" {
+		path = c.workspacePath
+	}
+	if path == "" {
+		fmt.Println("No file path provided")
+		return
+	}
+	c.writeWorkspace(path)
+}
+
+// writeWorkspace saves the workspace to path and remembers it as the file of the workspace.
+// Returns false if it could not be saved
+func (c *CLI) writeWorkspace(path string) bool {
+	if err := graph.WriteWorkspace(c.workspace, path); err != nil {
+		fmt.Printf("Error saving workspace: %v\n", err)
+		return false
+	}
+	c.workspacePath = path
+	c.savedState = c.workspaceState()
+	fmt.Printf("Workspace successfully saved to %s: %d graph(s)\n", path, len(c.workspace.Graphs))
+	return true
+}
+
+// toggleAutosave turns saving the workspace on exit on or off. The setting is saved with the workspace
+func (c *CLI) toggleAutosave() {
+	if c.workspace.Autosave {
+		c.workspace.Autosave = false
+		fmt.Println("Autosave on exit is off")
+		return
+	}
+	if c.workspacePath == "" {
+		var input string
+		fmt.Print("Enter workspace file path to save to on exit (.json): ")
+		fmt.Scanln(&input)
+		path := strings.TrimSpace(input)
+		if path == "" {
+			fmt.Println("No file path provided")
+			return
+		}
+		c.workspacePath = path
+	}
+	c.workspace.Autosave = true
+	fmt.Printf("Autosave on exit is on, the workspace will be saved to %s\n", c.workspacePath)
+}
+
+// Crash recovery: while the CLI runs, the workspace is written to the recovery file of the session
+// after every operation that changes it. The file is removed on a normal exit, so a file left by
+// a process that is not running any more means that session crashed. Every session has its own file
+// named by its process id, so sessions running at the same time do not touch each other's files
+
+// recoveryDir returns the directory of the recovery files
+func recoveryDir() string {
+	dir, err := os.UserCacheDir()
+	if err != nil {
+		dir = os.TempDir()
+	}
+	return filepath.Join(dir, "graph-cli")
+}
+
+// recoveryPath returns the path of the recovery file of the session with the process id pid
+func recoveryPath(pid int) string {
+	return filepath.Join(recoveryDir(), fmt.Sprintf("recovery-%d.json", pid))
+}
+
+// processRunning reports whether the process with the id pid is running. On Unix a process
+// is checked with the signal 0, elsewhere finding it is enough
+func processRunning(pid int) bool {
+	process, err := os.FindProcess(pid)
+	if err != nil {
+		return false
+	}
+	if runtime.GOOS == "windows" {
+		return true
+	}
+	err = process.Signal(syscall.Signal(0))
+	return err == nil || errors.Is(err, syscall.EPERM)
+}
+
+// writeRecovery writes the workspace to the recovery file if it has changed since it was written last.
+// The workspace is only written once it has graphs
+func (c *CLI) writeRecovery() {
+	state := c.workspaceState()
+	if state == nil || bytes.Equal(state, c.recoveryState) || (c.recoveryState == nil && len(c.workspace.Graphs) == 0) {
+		return
+	}
+	path := recoveryPath(os.Getpid())
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		return
+	}
+	if err := os.WriteFile(path, state, 0o600); err != nil {
+		return
+	}
+	c.recoveryState = state
+}
+
+// offerRecovery offers to restore the graphs of the sessions that did not exit properly.
+// The files of the sessions that are still running are left alone
+func (c *CLI) offerRecovery() {
+	paths, _ := filepath.Glob(filepath.Join(recoveryDir(), "recovery-*.json"))
+	restored := false
+	for _, path := range paths {
+		var pid int
+		if _, err := fmt.Sscanf(filepath.Base(path), "recovery-%d.json", &pid); err != nil ||
+			pid == os.Getpid() || processRunning(pid) {
+			continue
+		}
+
+		workspace, err := graph.ReadWorkspace(path)
+		if err != nil {
+			fmt.Printf("The recovery file %s can not be read: %v\n", path, err)
+			os.Remove(path)
+			continue
+		}
+
+		var input string
+		fmt.Printf("A session did not exit properly. Restore its %d graph(s)? (y/n): ", len(workspace.Graphs))
+		fmt.Scanln(&input)
+		os.Remove(path)
+		if strings.ToLower(strings.TrimSpace(input)) != "y" {
+			continue
+		}
+
+		// The graphs of several sessions are put into one workspace
+		if !restored {
+			c.workspace = workspace
+		} else {
+			for _, entry := range workspace.Graphs {
+				added := c.workspace.Add(c.workspace.UniqueName(entry.Name), entry.Graph)
+				added.Source, added.Metadata = entry.Source, entry.Metadata
+			}
+		}
+		restored = true
+		for _, entry := range workspace.Graphs {
+			fmt.Printf("Restored graph '%s': %d vertices, %d edges\n", entry.Name, len(entry.Graph.Nodes()), c.countEdges(entry.Graph))
+		}
+	}
+
+	if restored {
+		// Until it is saved the restored workspace is kept in the recovery file of this session
+		c.writeRecovery()
+		fmt.Println("Save the workspace to keep them")
+	}
+}
+
+// quit removes the recovery file and exits
+func (c *CLI) quit() {
+	os.Remove(recoveryPath(os.Getpid()))
+	fmt.Println("Goodbye!")
+	os.Exit(0)
+}
+
+func (c *CLI) exitProgram() {
+	var input string
+	if c.workspace.Autosave && c.workspacePath != "" {
+		fmt.Printf("Do you want to exit? The workspace will be saved to %s. (y/n): ", c.workspacePath)
+		fmt.Scanln(&input)
+		if strings.ToLower(strings.TrimSpace(input)) == "y" && (!c.unsaved() || c.writeWorkspace(c.workspacePath)) {
+			c.quit()
+		}
+		return
+	}
+
+	fmt.Print("Do you want to exit? All of your data will be lost, if not saved. (y/n): ")
+	fmt.Scanln(&input)
+
+	if strings.ToLower(strings.TrimSpace(input)) == "y" && c.confirmDiscard() {
+		c.quit()
+	}
+}
+
+// mainChangingOptions are the options of the main menu that change the workspace,
+// the recovery file is written after them
+var mainChangingOptions = map[int]bool{1: true, 2: true, 3: true, 4: true, 6: true}
+
+func (c *CLI) Run() {
+	fmt.Println("Welcome to Graph CLI!")
+	c.offerRecovery()
+
+	// The graphs may have changed since the recovery file was written last, so it is written again
+	defer func() {
+		if r := recover(); r != nil {
+			c.writeRecovery()
+			if c.recoveryState != nil {
+				fmt.Printf("\nThe program crashed. The graphs are kept in %s and will be offered on the next start\n", recoveryPath(os.Getpid()))
+			}
+			panic(r)
+		}
+	}()
+
+	for {
+		c.printMainMenu()
+
+		var input string
+		fmt.Scanln(&input)
+		choice, err := strconv.Atoi(strings.TrimSpace(input))
+		if err != nil {
+			fmt.Println("Invalid input. Please enter a number.")
+			continue
+		}
+
+		switch choice {
+		case 1:
+			c.selectGraph()
+		case 2:
+			c.addGraph()
+		case 3:
+			c.renameGraph()
+		case 4:
+			c.openWorkspace()
+		case 5:
+			c.saveWorkspace()
+		case 6:
+			c.toggleAutosave()
+		case 7:
+			c.exitProgram()
+		default:
+			fmt.Println("Invalid option. Please choose 1-7.")
+		}
+		if mainChangingOptions[choice] {
+			c.writeRecovery()
+		}
+	}
+}
+
+func (c *CLI) printGraphMenu() {
+	fmt.Println("\n=== Graph Operations ===")
+	fmt.Println("1. Add vertex")
+	fmt.Println("2. Add edge")
+	fmt.Println("3. Remove vertex")
+	fmt.Println("4. Remove edge")
+	fmt.Println("5. List vertices")
+	fmt.Println("6. List edges")
+	fmt.Println("7. Adjacency List / Matrix")
+	fmt.Println("8. Change graph type")
+	fmt.Println("9. Print graph info")
+	fmt.Println("10. Load from file")
+	fmt.Println("11. Save to file")
+	fmt.Println("12. TASK 2 Ia: List Knots")
+	fmt.Println("13. TASK 3 Ia")
+	fmt.Println("14. TASK 4 Ib: Remove Isolated Vertices")
+	fmt.Println("15. TASK 5 II: Calculate Cyclomatic Number")
+	fmt.Println("16. TASK 6 II: Find Vertex with Equal Path Lengths")
+	fmt.Println("17. TASK 7: Prim")
+	fmt.Println("18. TASK 8 IV a: Find Vertices Within Distance N")
+	fmt.Println("19. TASK 9 IV b: All Pairs Shortest Paths (Floyd-Warshall)")
+	fmt.Println("20. TASK 10 IV c: Single Source Shortest Paths (Bellman-Ford)")
+	fmt.Println("21. TASK 11 V (Потоки): Max Flow (Edmonds-Karp)")
+	fmt.Println("22. Set attribute")
+	fmt.Println("23. Get attribute")
+	fmt.Println("24. List attributes")
+	fmt.Println("25. Render to SVG")
+	fmt.Println("26. Back to main menu")
+	fmt.Print("Choose an option: ")
+}
+
+// graphChangingOptions are the options of the graph menu that change the graph or its entry,
+// the recovery file is written after them
+var graphChangingOptions = map[int]bool{1: true, 2: true, 3: true, 4: true, 8: true, 10: true, 11: true, 14: true, 22: true}
+
+func (c *CLI) graphOperationsMenu() {
+	entry := c.workspace.ActiveGraph()
+	if entry == nil {
+		fmt.Println("No active graph selected.")
+		return
+	}
+	// The graph may have just been added or selected
+	c.writeRecovery()
+
+	for {
+		// Loading from a file replaces the graph of the entry
+		currentGraph := entry.Graph
+		c.printGraphMenu()
+
+		var input string
+		fmt.Scanln(&input)
+		choice, err := strconv.Atoi(strings.TrimSpace(input))
+		if err != nil {
+			fmt.Println("Invalid input. Please enter a number.")
+			continue
+		}
+
+		switch choice {
+		case 1:
+			c.addVertex(currentGraph)
+		case 2:
+			c.addEdge(currentGraph)
+		case 3:
+			c.removeVertex(currentGraph)
+		case 4:
+			c.removeEdge(currentGraph)
+		case 5:
+			c.listVertices(currentGraph)
+		case 6:
+			c.listEdges(currentGraph, false)
+		case 7:
+			c.adjacencyList(currentGraph)
+		case 8:
+			c.changeGraphType(currentGraph)
+		case 9:
+			c.printGraphInfo(currentGraph)
+		case 10:
+			c.loadFromFile(currentGraph)
+		case 11:
+			c.saveToFile(currentGraph)
+		case 12:
+			c.listKnots(currentGraph)
+		case 13:
+			c.task3(currentGraph)
+		case 14:
+			c.task4(currentGraph)
+		case 15:
+			c.task5(currentGraph)
+		case 16:
+			c.findCommonVertexWithEqualPaths(currentGraph)
+		case 17:
+			c.findMinimumSpanningTreePrim(currentGraph)
+		case 18:
+			c.findVerticesWithinDistance(currentGraph)
+		case 19:
+			c.findAllPairsShortestPathsSimple(currentGraph)
+		case 20:
+			c.findShortestPathsFromVertex(currentGraph)
+		case 21:
+			c.findMaxFlowEdmondsKarp(currentGraph)
+		case 22:
+			c.setAttribute(currentGraph)
+		case 23:
+			c.getAttribute(currentGraph)
+		case 24:
+			c.listAttributes(currentGraph)
+		case 25:
+			c.renderSVG(currentGraph)
+		case 26:
+			return
+		default:
+			fmt.Println("Invalid option. Please choose 1-26.")
+		}
+		if graphChangingOptions[choice] {
+			c.writeRecovery()
+		}
+	}
+}
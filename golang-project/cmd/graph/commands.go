@@ -0,0 +1,1144 @@
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"math"
+	"path/filepath"
+	"strconv"
+	"strings"
+
+	"github.com/NomenConservandum/graph-theory/golang-project/graph"
+)
+
+// Неинтерактивный режим для скриптов и Makefile: graph <команда> [флаги] [аргументы] [файл].
+// Граф читается из файла (формат по расширению или по содержимому, либо --in) или, если файла нет
+// или он "-", со стандартного ввода. Команды, которые меняют граф, пишут его на стандартный вывод
+// или в файл -o, остальные выводят результат текстом или в JSON (--format json).
+// Флаги можно писать и после аргументов, "--" заканчивает флаги
+
+// The exit codes of the commands
+const (
+	exitOK       = 0 // done
+	exitNotFound = 1 // done, but the answer is "no": nothing found, a negative cycle, a disconnected graph
+	exitUsage    = 2 // invalid command line
+	exitFailure  = 3 // the graph can not be read or written or the command can not run on it
+)
+
+// commandError ends a command with the exit code. err is printed to the standard error if it is not nil
+type commandError struct {
+	code int
+	err  error
+}
+
+func (e *commandError) Error() string {
+	if e.err == nil {
+		return fmt.Sprintf("exit code %d", e.code)
+	}
+	return e.err.Error()
+}
+
+func usageError(format string, args ...interface{}) error {
+	return &commandError{code: exitUsage, err: fmt.Errorf(format, args...)}
+}
+
+func failure(format string, args ...interface{}) error {
+	return &commandError{code: exitFailure, err: fmt.Errorf(format, args...)}
+}
+
+// notFound ends a command whose answer is "no". Its result has been written already
+func notFound(format string, args ...interface{}) error {
+	return &commandError{code: exitNotFound, err: fmt.Errorf(format, args...)}
+}
+
+// commandEnv is the standard streams of the commands
+type commandEnv struct {
+	stdin  io.Reader
+	stdout io.Writer
+	stderr io.Writer
+}
+
+type command struct {
+	name    string
+	usage   string // the arguments after the flags
+	summary string
+	run     func(f *commandFlags, args []string) error
+}
+
+// commands are the subcommands in the order of the menu operations
+var commands = []*command{
+	{"add-vertex", "VALUE [FILE]", "Add a vertex (menu 1)", runAddVertex},
+	{"add-edge", "FROM TO [FILE]", "Add an edge (menu 2)", runAddEdge},
+	{"remove-vertex", "VALUE [FILE]", "Remove a vertex with its edges (menu 3)", runRemoveVertex},
+	{"remove-edge", "(FROM TO | --id ID) [FILE]", "Remove an edge (menu 4)", runRemoveEdge},
+	{"vertices", "[FILE]", "List the vertices (menu 5)", runVertices},
+	{"edges", "[FILE]", "List the edges (menu 6)", runEdges},
+	{"adjacency", "[FILE]", "Show the adjacency list or the adjacency or incidence matrix (menu 7)", runAdjacency},
+	{"set-type", "[FILE]", "Change the type of the graph (menu 8)", runSetType},
+	{"info", "[FILE]", "Print the type and the size of the graph (menu 9)", runInfo},
+	{"convert", "[FILE]", "Read the graph and write it in another format (menu 10, 11)", runConvert},
+	{"knots", "[FILE]", "List the vertices with loops (menu 12)", runKnots},
+	{"lesser-indegree", "VALUE [FILE]", "List the vertices with a lesser in-degree than the vertex (menu 13)", runLesserInDegree},
+	{"remove-isolated", "[FILE]", "Remove the isolated vertices (menu 14)", runRemoveIsolated},
+	{"cyclomatic", "[FILE]", "Print the cyclomatic number (menu 15)", runCyclomatic},
+	{"equal-paths", "U V [FILE]", "Find a vertex reachable from U and V by paths of equal length (menu 16)", runEqualPaths},
+	{"mst", "[FILE]", "Find the minimum spanning tree by Prim (menu 17)", runMST},
+	{"within", "[FILE]", "List the vertices within distance N of the vertex (menu 18)", runWithin},
+	{"apsp", "[FILE]", "Find the shortest distances between all pairs by Floyd-Warshall (menu 19)", runAPSP},
+	{"sssp", "[FILE]", "Find the shortest paths from the vertex by Bellman-Ford (menu 20)", runSSSP},
+	{"maxflow", "[FILE]", "Find the max flow by Edmonds-Karp (menu 21)", runMaxFlow},
+}
+
+func findCommand(name string) *command {
+	for _, cmd := range commands {
+		if cmd.name == name {
+			return cmd
+		}
+	}
+	return nil
+}
+
+// runCommand runs the subcommand in args[0] and returns the exit code of the program
+func runCommand(args []string, env *commandEnv) int {
+	name := args[0]
+	switch name {
+	case "help", "-h", "-help", "--help":
+		if len(args) > 1 {
+			if cmd := findCommand(args[1]); cmd != nil {
+				// The flags of a command are added when it runs, -h prints them all
+				f := newCommandFlags(cmd, env)
+				f.SetOutput(env.stdout)
+				cmd.run(f, []string{"-h"})
+				return exitOK
+			}
+		}
+		printCommandsUsage(env.stdout)
+		return exitOK
+	}
+
+	cmd := findCommand(name)
+	if cmd == nil {
+		fmt.Fprintf(env.stderr, "graph: unknown command '%s'\n\n", name)
+		printCommandsUsage(env.stderr)
+		return exitUsage
+	}
+
+	err := cmd.run(newCommandFlags(cmd, env), args[1:])
+	if err == nil || errors.Is(err, flag.ErrHelp) {
+		return exitOK
+	}
+	var commandErr *commandError
+	if !errors.As(err, &commandErr) {
+		commandErr = &commandError{code: exitFailure, err: err}
+	}
+	if commandErr.err != nil {
+		fmt.Fprintf(env.stderr, "graph %s: %v\n", name, commandErr.err)
+	}
+	return commandErr.code
+}
+
+func printCommandsUsage(w io.Writer) {
+	fmt.Fprintln(w, "Usage: graph                                  start the interactive menu")
+	fmt.Fprintln(w, "       graph <command> [flags] [arguments] [FILE]")
+	fmt.Fprintln(w, "\nCommands:")
+	for _, cmd := range commands {
+		fmt.Fprintf(w, "  %-16s %s\n", cmd.name, cmd.summary)
+	}
+	fmt.Fprintln(w, "\nThe graph is read from FILE or, if it is omitted or \"-\", from the standard input.")
+	fmt.Fprintln(w, "The commands that change the graph write it to the standard output or to -o FILE.")
+	fmt.Fprintln(w, "Run 'graph help <command>' for its flags.")
+	fmt.Fprintln(w, "\nExit codes: 0 - done, 1 - nothing found (or a negative cycle, a disconnected graph),")
+	fmt.Fprintln(w, "2 - invalid command line, 3 - the graph can not be read, written or used")
+}
+
+// commandFlags are the flags of a command with the ones common to all the commands
+type commandFlags struct {
+	*flag.FlagSet
+	command *command
+	env     *commandEnv
+
+	in          string // the format of the input graph
+	inputFormat string // the format the graph was read in
+	format      string // the format of the result: text or json
+	output      string // the file the changed graph is written to
+	to          string // the format of the changed graph
+	draw        string // the file the result is drawn to
+}
+
+func newCommandFlags(cmd *command, env *commandEnv) *commandFlags {
+	f := &commandFlags{FlagSet: flag.NewFlagSet(cmd.name, flag.ContinueOnError), command: cmd, env: env}
+	f.SetOutput(env.stderr)
+	f.StringVar(&f.in, "in", "", "the format of the input graph, by default by the extension or the content: "+
+		strings.Join(readableFormats(), ", "))
+	f.Usage = func() {
+		fmt.Fprintf(f.Output(), "Usage: graph %s [flags] %s\n%s\n\nFlags:\n", cmd.name, cmd.usage, cmd.summary)
+		f.PrintDefaults()
+	}
+	return f
+}
+
+// resultFlags adds the flag of the format of the result
+func (f *commandFlags) resultFlags() {
+	f.StringVar(&f.format, "format", "text", "the format of the result: text or json")
+}
+
+// editFlags adds the flags of the output of the changed graph
+func (f *commandFlags) editFlags() {
+	f.StringVar(&f.output, "o", "", "write the graph to the file instead of the standard output")
+	f.StringVar(&f.to, "to", "", "the format of the graph written, by default by the extension of -o or the input format: "+
+		strings.Join(graph.FormatNames(), ", "))
+}
+
+// drawFlag adds the flag of the file the result is drawn to
+func (f *commandFlags) drawFlag() {
+	f.StringVar(&f.draw, "draw", "", "draw the graph with the result to the file: .svg, .typ (CeTZ), .tikz/.tex (TikZ), "+
+		".mmd (Mermaid), .puml (PlantUML), other - DOT")
+}
+
+// readableFormats returns the names of the formats a graph can be read in
+func readableFormats() []string {
+	var names []string
+	for _, format := range graph.Formats() {
+		if format.Decode != nil {
+			names = append(names, format.Name)
+		}
+	}
+	return names
+}
+
+// parseArgs parses the flags among args and returns the other arguments
+func (f *commandFlags) parseArgs(args []string) ([]string, error) {
+	var positional []string
+	for len(args) > 0 {
+		if err := f.Parse(args); err != nil {
+			if errors.Is(err, flag.ErrHelp) {
+				return nil, err
+			}
+			// The flag package has printed the error and the usage
+			return nil, &commandError{code: exitUsage}
+		}
+		rest := f.Args()
+		if consumed := len(args) - len(rest); consumed > 0 && args[consumed-1] == "--" {
+			positional = append(positional, rest...)
+			break
+		}
+		if len(rest) > 0 {
+			positional = append(positional, rest[0])
+			rest = rest[1:]
+		}
+		args = rest
+	}
+
+	if f.format != "" && f.format != "text" && f.format != "json" {
+		return nil, usageError("unknown result format '%s', expected text or json", f.format)
+	}
+	if f.in != "" {
+		if format := graph.FormatByName(f.in); format == nil || format.Decode == nil {
+			return nil, usageError("the format '%s' can not be read", f.in)
+		}
+	}
+	if f.to != "" {
+		if format := graph.FormatByName(f.to); format == nil || format.Encode == nil {
+			return nil, usageError("the format '%s' can not be written", f.to)
+		}
+	}
+	return positional, nil
+}
+
+// parse parses args, which must have n arguments and optionally the file of the graph after them, and reads
+// the graph. Returns the arguments without the file
+func (f *commandFlags) parse(args []string, n int) ([]string, *graph.GraphInfo, error) {
+	positional, err := f.parseArgs(args)
+	if err != nil {
+		return nil, nil, err
+	}
+	g, err := f.graphAfter(positional, n)
+	if err != nil {
+		return nil, nil, err
+	}
+	return positional[:n], g, nil
+}
+
+// graphAfter reads the graph from the file in positional[n], from the standard input if there is none
+func (f *commandFlags) graphAfter(positional []string, n int) (*graph.GraphInfo, error) {
+	switch {
+	case len(positional) < n:
+		return nil, usageError("expected %s", f.command.usage)
+	case len(positional) > n+1:
+		return nil, usageError("too many arguments: %s", strings.Join(positional[n+1:], " "))
+	case len(positional) == n+1:
+		return f.readGraph(positional[n])
+	}
+	return f.readGraph("-")
+}
+
+// readGraph reads the graph from the file at path or, if path is "-", from the standard input
+func (f *commandFlags) readGraph(path string) (*graph.GraphInfo, error) {
+	format := f.in
+	var g *graph.GraphInfo
+	if path == "-" {
+		data, err := io.ReadAll(f.env.stdin)
+		if err != nil {
+			return nil, failure("can not read the standard input: %v", err)
+		}
+		if format == "" {
+			format = "text"
+			if detected := graph.DetectFormat(data); detected != nil {
+				format = detected.Name
+			}
+		}
+		g, err = graph.Decode(bytes.NewReader(data), format)
+		if err != nil {
+			return nil, &commandError{code: exitFailure, err: err}
+		}
+	} else {
+		if format == "" {
+			detected, err := graph.FileFormat(path)
+			if err != nil {
+				return nil, &commandError{code: exitFailure, err: err}
+			}
+			format = detected.Name
+		}
+		var err error
+		if g, err = graph.ReadFile(path, format); err != nil {
+			return nil, &commandError{code: exitFailure, err: err}
+		}
+	}
+	f.inputFormat = format
+	return g, nil
+}
+
+// writeGraph writes the changed graph to -o or the standard output in the format of --to,
+// of the extension of -o or of the input, the text format if it can not be written
+func (f *commandFlags) writeGraph(g *graph.GraphInfo) error {
+	format := f.to
+	if format == "" && f.output != "" {
+		if byExtension := graph.FormatByExtension(f.output); byExtension != nil {
+			format = byExtension.Name
+		}
+	}
+	if format == "" {
+		format = f.inputFormat
+	}
+	if known := graph.FormatByName(format); known == nil || known.Encode == nil {
+		format = "text"
+	}
+
+	if f.output == "" || f.output == "-" {
+		if err := graph.Encode(g, f.env.stdout, format); err != nil {
+			return failure("%v", err)
+		}
+		return nil
+	}
+	if err := graph.WriteFile(g, f.output, format); err != nil {
+		return failure("%v", err)
+	}
+	return nil
+}
+
+// drawResult draws the graph with the result to the file of --draw, if it is set
+func (f *commandFlags) drawResult(g *graph.GraphInfo, diagram *graph.Diagram) error {
+	if f.draw == "" {
+		return nil
+	}
+	err := graph.WriteFileWith(f.draw, func(w io.Writer) error {
+		switch strings.ToLower(filepath.Ext(f.draw)) {
+		case ".svg":
+			return graph.EncodeSVG(g, w, diagram)
+		case ".typ":
+			return graph.EncodeCeTZ(g, w, diagram)
+		case ".tikz", ".tex":
+			return graph.EncodeTikZ(g, w, diagram)
+		case ".mmd", ".mermaid":
+			return graph.EncodeMermaid(g, w, diagram)
+		case ".puml", ".plantuml":
+			return graph.EncodePlantUML(g, w, diagram)
+		}
+		var highlight *graph.Highlight
+		if len(diagram.Highlights) > 0 {
+			highlight = diagram.Highlights[0]
+		}
+		return graph.EncodeDOT(g, w, highlight)
+	})
+	if err != nil {
+		return failure("%v", err)
+	}
+	return nil
+}
+
+// writeJSON writes v to the standard output as indented JSON
+func (f *commandFlags) writeJSON(v interface{}) error {
+	data, err := json.Marshal(v)
+	if err != nil {
+		return failure("%v", err)
+	}
+	return f.writeJSONData(data)
+}
+
+// writeJSONData writes the JSON document to the standard output indented
+func (f *commandFlags) writeJSONData(data []byte) error {
+	var indented bytes.Buffer
+	if err := json.Indent(&indented, data, "", "  "); err != nil {
+		return failure("%v", err)
+	}
+	indented.WriteString("\n")
+	_, err := f.env.stdout.Write(indented.Bytes())
+	return err
+}
+
+func (f *commandFlags) json() bool {
+	return f.format == "json"
+}
+
+func (f *commandFlags) printf(format string, args ...interface{}) {
+	fmt.Fprintf(f.env.stdout, format, args...)
+}
+
+// vertexArg returns the vertex with the value written in s
+func vertexArg(g *graph.GraphInfo, s string) (*graph.Node, error) {
+	value, err := g.ParseValue(s)
+	if err != nil {
+		return nil, failure("invalid vertex '%s': %v", s, err)
+	}
+	node := graph.FindNodeByValue(g, value)
+	if node == nil {
+		return nil, failure("there is no vertex '%s'", s)
+	}
+	return node, nil
+}
+
+// requiredVertex returns the vertex of a flag that must be set
+func requiredVertex(g *graph.GraphInfo, s string, flagName string) (*graph.Node, error) {
+	if s == "" {
+		return nil, usageError("the flag --%s is required", flagName)
+	}
+	return vertexArg(g, s)
+}
+
+// formatNumber writes a weight or a distance exactly, the infinities as INF and -INF
+func formatNumber(x float64) string {
+	switch {
+	case math.IsInf(x, 1):
+		return "INF"
+	case math.IsInf(x, -1):
+		return "-INF"
+	}
+	return strconv.FormatFloat(x, 'g', -1, 64)
+}
+
+// values returns the values of the vertices as text
+func values(nodes []*graph.Node) []string {
+	result := make([]string, 0, len(nodes))
+	for _, node := range nodes {
+		result = append(result, fmt.Sprint(node.Value))
+	}
+	return result
+}
+
+// printValues writes the values of the vertices one per line or as a JSON object with them under key
+func (f *commandFlags) printValues(key string, nodes []*graph.Node) error {
+	if f.json() {
+		return f.writeJSON(map[string][]string{key: values(nodes)})
+	}
+	for _, value := range values(nodes) {
+		f.printf("%s\n", value)
+	}
+	return nil
+}
+
+// edgeText writes the edge as "A -> B" or "A -- B" with its weight
+func edgeText(g *graph.GraphInfo, edge *graph.Edge) string {
+	op := "--"
+	if edge.IsOriented() {
+		op = "->"
+	}
+	text := fmt.Sprintf("%v %s %v", edge.List[0].Value, op, edge.List[1].Value)
+	if g.IsWeighted() {
+		text += " " + formatNumber(edge.Weight)
+	}
+	return text
+}
+
+// 1. Add vertex
+func runAddVertex(f *commandFlags, args []string) error {
+	f.editFlags()
+	arguments, g, err := f.parse(args, 1)
+	if err != nil {
+		return err
+	}
+	value, err := g.ParseValue(arguments[0])
+	if err != nil {
+		return failure("invalid vertex '%s': %v", arguments[0], err)
+	}
+	if err := graph.AddVertex(g, graph.NodeConstructor(value)); err != nil {
+		return failure("%v", err)
+	}
+	return f.writeGraph(g)
+}
+
+// 2. Add edge
+func runAddEdge(f *commandFlags, args []string) error {
+	f.editFlags()
+	weightText := f.String("weight", "", "the weight of the edge in a weighted graph, 0 by default")
+	directed := f.Bool("directed", false, "add an oriented edge to a mixed graph")
+	undirected := f.Bool("undirected", false, "add a non-oriented edge to a mixed graph")
+	arguments, g, err := f.parse(args, 2)
+	if err != nil {
+		return err
+	}
+	from, err := vertexArg(g, arguments[0])
+	if err != nil {
+		return err
+	}
+	to, err := vertexArg(g, arguments[1])
+	if err != nil {
+		return err
+	}
+
+	var weight float64
+	if *weightText != "" {
+		if !g.IsWeighted() {
+			return failure("the graph is unweighted")
+		}
+		if weight, _, err = g.ParseWeight(*weightText); err != nil {
+			return usageError("%v", err)
+		}
+	}
+
+	oriented := g.IsOriented()
+	if *directed && *undirected {
+		return usageError("--directed and --undirected can not be used together")
+	}
+	if *directed || *undirected {
+		if !g.IsMixed() && *directed != g.IsOriented() {
+			return failure("the direction of the edge does not match the type of the graph, it is not mixed")
+		}
+		oriented = *directed
+	}
+
+	switch {
+	case oriented:
+		err = graph.AddEdge(g, from, to, weight)
+	case g.IsWeighted():
+		err = graph.AddNonOrientedEdge(g, from, to, weight)
+	default:
+		err = graph.AddNonOrientedNonWeightedEdge(g, from, to)
+	}
+	if err != nil {
+		return failure("%v", err)
+	}
+	return f.writeGraph(g)
+}
+
+// 3. Remove vertex
+func runRemoveVertex(f *commandFlags, args []string) error {
+	f.editFlags()
+	arguments, g, err := f.parse(args, 1)
+	if err != nil {
+		return err
+	}
+	node, err := vertexArg(g, arguments[0])
+	if err != nil {
+		return err
+	}
+	graph.RemoveVertex(g, node)
+	return f.writeGraph(g)
+}
+
+// 4. Remove edge
+func runRemoveEdge(f *commandFlags, args []string) error {
+	f.editFlags()
+	id := f.Int("id", -1, "the id of the edge, as listed by 'graph edges --ids'")
+	positional, err := f.parseArgs(args)
+	if err != nil {
+		return err
+	}
+	n := 2
+	if *id >= 0 {
+		n = 0
+	}
+	g, err := f.graphAfter(positional, n)
+	if err != nil {
+		return err
+	}
+
+	var edge *graph.Edge
+	if *id >= 0 {
+		if edge = g.EdgeById(uint32(*id)); edge == nil {
+			return failure("there is no edge %d", *id)
+		}
+	} else {
+		from, err := vertexArg(g, positional[0])
+		if err != nil {
+			return err
+		}
+		to, err := vertexArg(g, positional[1])
+		if err != nil {
+			return err
+		}
+		// The first of the parallel edges is removed
+		for _, e := range graph.GetAllEdges(g) {
+			if (e.List[0] == from && e.List[1] == to) || (!e.IsOriented() && e.List[0] == to && e.List[1] == from) {
+				edge = e
+				break
+			}
+		}
+		if edge == nil {
+			return failure("there is no edge from '%s' to '%s'", positional[0], positional[1])
+		}
+	}
+	graph.RemoveEdge(g, edge)
+	return f.writeGraph(g)
+}
+
+// 5. List vertices
+func runVertices(f *commandFlags, args []string) error {
+	f.resultFlags()
+	_, g, err := f.parse(args, 0)
+	if err != nil {
+		return err
+	}
+	return f.printValues("vertices", g.Nodes())
+}
+
+type jsonListedEdge struct {
+	ID       uint32   `json:"id"`
+	From     string   `json:"from"`
+	To       string   `json:"to"`
+	Oriented bool     `json:"oriented"`
+	Weight   *float64 `json:"weight,omitempty"`
+}
+
+// 6. List edges
+func runEdges(f *commandFlags, args []string) error {
+	f.resultFlags()
+	ids := f.Bool("ids", false, "write the ids of the edges first")
+	_, g, err := f.parse(args, 0)
+	if err != nil {
+		return err
+	}
+
+	edges := graph.GetAllEdges(g)
+	if f.json() {
+		listed := make([]jsonListedEdge, 0, len(edges))
+		for _, edge := range edges {
+			e := jsonListedEdge{ID: edge.Id, From: fmt.Sprint(edge.List[0].Value), To: fmt.Sprint(edge.List[1].Value), Oriented: edge.IsOriented()}
+			if g.IsWeighted() {
+				weight := edge.Weight
+				e.Weight = &weight
+			}
+			listed = append(listed, e)
+		}
+		return f.writeJSON(map[string][]jsonListedEdge{"edges": listed})
+	}
+	for _, edge := range edges {
+		if *ids {
+			f.printf("%d ", edge.Id)
+		}
+		f.printf("%s\n", edgeText(g, edge))
+	}
+	return nil
+}
+
+type jsonAdjacency struct {
+	Vertex     string   `json:"vertex"`
+	Neighbours []string `json:"neighbours"`
+}
+
+// 7. Adjacency list / matrix
+func runAdjacency(f *commandFlags, args []string) error {
+	f.resultFlags()
+	view := f.String("view", "list", "list, matrix (adjacency matrix) or incidence (incidence matrix)")
+	_, g, err := f.parse(args, 0)
+	if err != nil {
+		return err
+	}
+
+	switch *view {
+	case "list":
+	case "matrix", "incidence":
+		if f.json() {
+			return usageError("--format json is only for --view list")
+		}
+		encode, options := graph.EncodeIncidenceMatrix, graph.MatrixOptions{Header: true}
+		if *view == "matrix" {
+			encode = graph.EncodeAdjacencyMatrix
+			// A missing edge of a weighted graph is INF, so that it differs from the weight 0
+			if g.IsWeighted() {
+				options.NoEdge = graph.NoEdgeInf
+			}
+		}
+		if err := encode(g, f.env.stdout, options); err != nil {
+			return failure("%v", err)
+		}
+		return nil
+	default:
+		return usageError("unknown view '%s', expected list, matrix or incidence", *view)
+	}
+
+	list := make([]jsonAdjacency, 0, len(g.Nodes()))
+	for _, node := range g.Nodes() {
+		neighbours := make([]string, 0)
+		for _, edge := range g.OutEdges(node) {
+			neighbours = append(neighbours, fmt.Sprint(edge.Other(node).Value))
+		}
+		list = append(list, jsonAdjacency{Vertex: fmt.Sprint(node.Value), Neighbours: neighbours})
+	}
+	if f.json() {
+		return f.writeJSON(map[string][]jsonAdjacency{"adjacency": list})
+	}
+	for _, a := range list {
+		f.printf("%s:", a.Vertex)
+		for _, neighbour := range a.Neighbours {
+			f.printf(" %s", neighbour)
+		}
+		f.printf("\n")
+	}
+	return nil
+}
+
+// 8. Change graph type. The reports of the conversions go to the standard error
+func runSetType(f *commandFlags, args []string) error {
+	f.editFlags()
+	directed := f.Bool("directed", false, "make the graph directed")
+	undirected := f.Bool("undirected", false, "make the graph undirected")
+	mixed := f.Bool("mixed", false, "make the graph mixed, every edge keeps its direction")
+	weighted := f.Bool("weighted", false, "make the graph weighted")
+	unweighted := f.Bool("unweighted", false, "make the graph unweighted")
+	bothArcs := f.Bool("both-arcs", false, "--directed: turn every non-oriented edge into both arcs instead of orienting it from the first end")
+	merge := f.String("merge", "min", "--undirected: the weight of merged opposite edges: min, max or sum")
+	defaultWeight := f.Float64("default-weight", 1, "--weighted: the weight of the edges")
+	_, g, err := f.parse(args, 0)
+	if err != nil {
+		return err
+	}
+
+	count := 0
+	for _, set := range []bool{*directed, *undirected, *mixed} {
+		if set {
+			count++
+		}
+	}
+	if count > 1 || (*weighted && *unweighted) {
+		return usageError("conflicting type flags")
+	}
+	policy := graph.WeightMin
+	switch *merge {
+	case "min":
+	case "max":
+		policy = graph.WeightMax
+	case "sum":
+		policy = graph.WeightSum
+	default:
+		return usageError("unknown merge policy '%s', expected min, max or sum", *merge)
+	}
+
+	var reports []*graph.ConversionReport
+	switch {
+	case *mixed:
+		g.SetMixed(true)
+	case *directed && (!g.IsOriented() || g.IsMixed()):
+		reports = append(reports, graph.ConvertToDirected(g, *bothArcs))
+	case *undirected && g.IsOriented():
+		reports = append(reports, graph.ConvertToUndirected(g, policy))
+	}
+	if *weighted && !g.IsWeighted() {
+		reports = append(reports, graph.ConvertToWeighted(g, *defaultWeight))
+	} else if *unweighted && g.IsWeighted() {
+		reports = append(reports, graph.ConvertToUnweighted(g))
+	}
+
+	for _, report := range reports {
+		fmt.Fprintln(f.env.stderr, report)
+	}
+	return f.writeGraph(g)
+}
+
+// 9. Print graph info
+func runInfo(f *commandFlags, args []string) error {
+	f.resultFlags()
+	_, g, err := f.parse(args, 0)
+	if err != nil {
+		return err
+	}
+
+	if f.json() {
+		return f.writeJSON(struct {
+			Oriented   bool `json:"oriented"`
+			Weighted   bool `json:"weighted"`
+			Mixed      bool `json:"mixed"`
+			Multigraph bool `json:"multigraph"`
+			Vertices   int  `json:"vertices"`
+			Edges      int  `json:"edges"`
+		}{g.IsOriented(), g.IsWeighted(), g.IsMixed(), g.IsMultigraph(), len(g.Nodes()), graph.CountEdges(g)})
+	}
+	orientation := map[bool]string{true: "oriented", false: "non-oriented"}[g.IsOriented()]
+	if g.IsMixed() {
+		orientation = "mixed"
+	}
+	f.printf("type: %s, %s\n", orientation, map[bool]string{true: "weighted", false: "non-weighted"}[g.IsWeighted()])
+	f.printf("multigraph: %v\n", g.IsMultigraph())
+	f.printf("vertices: %d\n", len(g.Nodes()))
+	f.printf("edges: %d\n", graph.CountEdges(g))
+	return nil
+}
+
+// 10, 11. Load from file, save to file
+func runConvert(f *commandFlags, args []string) error {
+	f.editFlags()
+	_, g, err := f.parse(args, 0)
+	if err != nil {
+		return err
+	}
+	return f.writeGraph(g)
+}
+
+// 12. Knots
+func runKnots(f *commandFlags, args []string) error {
+	f.resultFlags()
+	f.drawFlag()
+	_, g, err := f.parse(args, 0)
+	if err != nil {
+		return err
+	}
+	if !g.IsOriented() {
+		return failure("knots are only listed in directed graphs")
+	}
+
+	knots := graph.Knots(g)
+	if err := f.printValues("knots", knots); err != nil {
+		return err
+	}
+	if err := f.drawResult(g, &graph.Diagram{Classes: map[string][]*graph.Node{"knot": knots}}); err != nil {
+		return err
+	}
+	if len(knots) == 0 {
+		return notFound("no vertices with loops")
+	}
+	return nil
+}
+
+// 13. Vertices with a lesser in-degree
+func runLesserInDegree(f *commandFlags, args []string) error {
+	f.resultFlags()
+	arguments, g, err := f.parse(args, 1)
+	if err != nil {
+		return err
+	}
+	node, err := vertexArg(g, arguments[0])
+	if err != nil {
+		return err
+	}
+
+	nodes := graph.VerticesWithLesserInDegree(g, node)
+	if err := f.printValues("vertices", nodes); err != nil {
+		return err
+	}
+	if len(nodes) == 0 {
+		return notFound("no vertices with a lesser in-degree than '%s'", arguments[0])
+	}
+	return nil
+}
+
+// 14. Remove isolated vertices. The removed vertices are listed on the standard error
+func runRemoveIsolated(f *commandFlags, args []string) error {
+	f.editFlags()
+	_, g, err := f.parse(args, 0)
+	if err != nil {
+		return err
+	}
+	for _, node := range graph.IsolatedVertices(g) {
+		fmt.Fprintf(f.env.stderr, "Removing vertex '%v'\n", node.Value)
+		graph.RemoveVertex(g, node)
+	}
+	return f.writeGraph(g)
+}
+
+// 15. Cyclomatic number
+func runCyclomatic(f *commandFlags, args []string) error {
+	f.resultFlags()
+	_, g, err := f.parse(args, 0)
+	if err != nil {
+		return err
+	}
+
+	number := graph.CyclomaticNumber(g)
+	if f.json() {
+		return f.writeJSON(struct {
+			Cyclomatic int `json:"cyclomatic"`
+			Edges      int `json:"edges"`
+			Vertices   int `json:"vertices"`
+			Components int `json:"components"`
+		}{number, graph.CountEdges(g), len(g.Nodes()), graph.CountConnectedComponents(g)})
+	}
+	f.printf("%d\n", number)
+	return nil
+}
+
+// 16. Vertex with equal path lengths
+func runEqualPaths(f *commandFlags, args []string) error {
+	f.resultFlags()
+	arguments, g, err := f.parse(args, 2)
+	if err != nil {
+		return err
+	}
+	u, err := vertexArg(g, arguments[0])
+	if err != nil {
+		return err
+	}
+	v, err := vertexArg(g, arguments[1])
+	if err != nil {
+		return err
+	}
+	if u == v {
+		return usageError("the vertices u and v must be different")
+	}
+
+	// As in the menu: the shortest paths first, then all the paths
+	target, length := graph.FindCommonVertexWithEqualPathLength(g, u, v)
+	if target == nil {
+		target, length = graph.FindCommonVertexWithEqualPathLengthAllPaths(g, u, v)
+	}
+
+	if f.json() {
+		var vertex *string
+		if target != nil {
+			value := fmt.Sprint(target.Value)
+			vertex = &value
+		}
+		if err := f.writeJSON(struct {
+			Vertex *string `json:"vertex"`
+			Length int     `json:"length"`
+		}{vertex, length}); err != nil {
+			return err
+		}
+	} else if target != nil {
+		f.printf("%v %d\n", target.Value, length)
+	}
+	if target == nil {
+		return notFound("no vertex is reachable from '%s' and '%s' by paths of equal length", arguments[0], arguments[1])
+	}
+	return nil
+}
+
+// 17. Prim
+func runMST(f *commandFlags, args []string) error {
+	f.resultFlags()
+	f.drawFlag()
+	start := f.String("start", "", "the start vertex, by default the best of all the starts")
+	_, g, err := f.parse(args, 0)
+	if err != nil {
+		return err
+	}
+	if g.IsOriented() {
+		return failure("Prim's algorithm only works for undirected graphs")
+	}
+
+	var result *graph.PrimResult
+	if *start == "" {
+		result = graph.PrimAllStarts(g)
+	} else {
+		node, err := vertexArg(g, *start)
+		if err != nil {
+			return err
+		}
+		result = graph.Prim(g, node)
+	}
+
+	if f.json() {
+		data, err := result.MarshalJSON()
+		if err != nil {
+			return failure("%v", err)
+		}
+		if err := f.writeJSONData(data); err != nil {
+			return err
+		}
+	} else {
+		for _, edge := range result.MSTEdges {
+			line := fmt.Sprintf("%v -- %v", edge.List[0].Value, edge.List[1].Value)
+			if g.IsWeighted() {
+				line += " " + formatNumber(edge.Weight)
+			}
+			f.printf("%s\n", line)
+		}
+		f.printf("Total weight: %s\n", formatNumber(result.TotalWeight))
+	}
+	if err := f.drawResult(g, mstDiagram(result)); err != nil {
+		return err
+	}
+	if !result.IsConnected {
+		return notFound("the graph is not connected, the tree spans one component")
+	}
+	return nil
+}
+
+// 18. Vertices within distance N
+func runWithin(f *commandFlags, args []string) error {
+	f.resultFlags()
+	f.drawFlag()
+	vertex := f.String("vertex", "", "the vertex (required)")
+	distance := f.Float64("n", -1, "the distance N (required)")
+	_, g, err := f.parse(args, 0)
+	if err != nil {
+		return err
+	}
+	if *distance < 0 {
+		return usageError("the flag -n is required and must not be negative")
+	}
+	node, err := requiredVertex(g, *vertex, "vertex")
+	if err != nil {
+		return err
+	}
+	if !g.IsOriented() {
+		return failure("this operation is intended for oriented graphs only")
+	}
+
+	vertices := graph.FindVerticesWithinDistance(g, node, *distance)
+	if err := f.printValues("vertices", vertices); err != nil {
+		return err
+	}
+	diagram := &graph.Diagram{Classes: map[string][]*graph.Node{"start": {node}, "within": vertices}}
+	if err := f.drawResult(g, diagram); err != nil {
+		return err
+	}
+	if len(vertices) == 0 {
+		return notFound("no vertices within the distance")
+	}
+	return nil
+}
+
+// 19. Floyd-Warshall
+func runAPSP(f *commandFlags, args []string) error {
+	f.resultFlags()
+	_, g, err := f.parse(args, 0)
+	if err != nil {
+		return err
+	}
+
+	distances, hasNegativeCycle := graph.FloydWarshallSimple(g)
+	if f.json() {
+		data, err := graph.DistancesJSON(g.Nodes(), distances, hasNegativeCycle)
+		if err != nil {
+			return failure("%v", err)
+		}
+		if err := f.writeJSONData(data); err != nil {
+			return err
+		}
+	} else if !hasNegativeCycle {
+		printDistanceMatrix(f.env.stdout, g.Nodes(), distances)
+	}
+	if hasNegativeCycle {
+		return notFound("the graph contains a negative cycle")
+	}
+	return nil
+}
+
+// 20. Bellman-Ford. A line of the text result is the vertex, its distance and the path to it
+func runSSSP(f *commandFlags, args []string) error {
+	f.resultFlags()
+	f.drawFlag()
+	from := f.String("from", "", "the start vertex (required)")
+	_, g, err := f.parse(args, 0)
+	if err != nil {
+		return err
+	}
+	start, err := requiredVertex(g, *from, "from")
+	if err != nil {
+		return err
+	}
+
+	result := graph.BellmanFord(g, start)
+	if f.json() {
+		data, err := result.MarshalJSON()
+		if err != nil {
+			return failure("%v", err)
+		}
+		if err := f.writeJSONData(data); err != nil {
+			return err
+		}
+	} else {
+		for _, node := range g.Nodes() {
+			distance := result.Distances[node]
+			f.printf("%v %s", node.Value, formatNumber(distance))
+			if path := result.ReconstructPath(node); path != nil && !math.IsInf(distance, 0) {
+				f.printf(" %s", strings.Join(values(path), " -> "))
+			}
+			f.printf("\n")
+		}
+	}
+	if err := f.drawResult(g, shortestPathsDiagram(result)); err != nil {
+		return err
+	}
+	if result.HasNegativeCycle {
+		return notFound("the graph contains a negative cycle reachable from '%s'", *from)
+	}
+	return nil
+}
+
+// 21. Edmonds-Karp
+func runMaxFlow(f *commandFlags, args []string) error {
+	f.resultFlags()
+	f.drawFlag()
+	var sourceValue, sinkValue string
+	f.StringVar(&sourceValue, "s", "", "the source, by default the one set in the graph (e.g. in a DIMACS file)")
+	f.StringVar(&sourceValue, "source", "", "the same as -s")
+	f.StringVar(&sinkValue, "t", "", "the sink, by default the one set in the graph")
+	f.StringVar(&sinkValue, "sink", "", "the same as -t")
+	_, g, err := f.parse(args, 0)
+	if err != nil {
+		return err
+	}
+
+	source, sink := graph.FlowTerminals(g)
+	if sourceValue != "" {
+		if source, err = vertexArg(g, sourceValue); err != nil {
+			return err
+		}
+	}
+	if sinkValue != "" {
+		if sink, err = vertexArg(g, sinkValue); err != nil {
+			return err
+		}
+	}
+	if source == nil || sink == nil {
+		return usageError("the source (-s) and the sink (-t) are not set in the graph and must be given")
+	}
+	if source == sink {
+		return usageError("the source and the sink must not be the same vertex")
+	}
+
+	// The warnings go to stderr, so that they do not mix with the result (e.g. --format json)
+	network, skipped := graph.CreateFlowNetwork(g)
+	for _, edge := range skipped {
+		fmt.Fprintf(f.env.stderr, "Warning: edge from %v to %v has negative capacity %s, skipped\n",
+			edge.List[0].Value, edge.List[1].Value, formatNumber(edge.Weight))
+	}
+	result := graph.EdmondsKarp(network, source, sink)
+	if f.json() {
+		data, err := result.MarshalJSON()
+		if err != nil {
+			return failure("%v", err)
+		}
+		if err := f.writeJSONData(data); err != nil {
+			return err
+		}
+	} else {
+		f.printf("Max flow: %s\n", formatNumber(result.MaxFlowValue))
+		for _, edge := range network.Edges {
+			if flow := result.Flow[edge]; flow > 0 {
+				f.printf("%v -> %v %s/%s\n", edge.From.Value, edge.To.Value, formatNumber(flow), formatNumber(edge.Capacity))
+			}
+		}
+		if len(result.MinCut) > 0 {
+			f.printf("Min cut:\n")
+			for _, edge := range result.MinCut {
+				f.printf("%v -> %v %s\n", edge.From.Value, edge.To.Value, formatNumber(edge.Capacity))
+			}
+		}
+	}
+	return f.drawResult(g, maxFlowDiagram(g, result))
+}
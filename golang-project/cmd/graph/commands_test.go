@@ -0,0 +1,125 @@
+package main
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/NomenConservandum/graph-theory/golang-project/graph"
+)
+
+const testRoads = `TYPE: UNDIRECTED WEIGHTED
+VERTICES: A,B,C,D
+A B 1
+B C 2
+`
+
+const testNegativeCycle = `TYPE: DIRECTED WEIGHTED
+VERTICES: A,B
+A B -1
+B A -1
+`
+
+// run runs the command with stdin and returns its exit code, standard output and standard error
+func run(t *testing.T, stdin string, args ...string) (int, string, string) {
+	t.Helper()
+	var stdout, stderr bytes.Buffer
+	code := runCommand(args, &commandEnv{stdin: strings.NewReader(stdin), stdout: &stdout, stderr: &stderr})
+	return code, stdout.String(), stderr.String()
+}
+
+func TestCommandExitCodes(t *testing.T) {
+	workspace := filepath.Join(t.TempDir(), "session.json")
+	if err := graph.WriteWorkspace(graph.NewWorkspace(), workspace); err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name   string
+		stdin  string
+		args   []string
+		code   int
+		stdout string // a part of the standard output
+		stderr string // a part of the standard error
+	}{
+		{"info", testRoads, []string{"info"}, exitOK, "vertices: 4\nedges: 2\n", ""},
+		{"info from -", testRoads, []string{"info", "--format", "json", "-"}, exitOK, `"vertices": 4`, ""},
+		{"vertices", testRoads, []string{"vertices"}, exitOK, "A\nB\nC\nD\n", ""},
+		{"help", "", []string{"help"}, exitOK, "Exit codes:", ""},
+		{"disconnected", testRoads, []string{"mst"}, exitNotFound, "Total weight: 3\n", "graph mst: the graph is not connected"},
+		{"negative cycle", testNegativeCycle, []string{"sssp", "--from", "A"}, exitNotFound, "A -INF\n", "negative cycle"},
+		{"unknown command", "", []string{"bogus"}, exitUsage, "", "graph: unknown command 'bogus'"},
+		{"unknown flag", testRoads, []string{"info", "--nope"}, exitUsage, "", "flag provided but not defined: -nope"},
+		{"missing argument", testRoads, []string{"add-vertex"}, exitUsage, "", "graph add-vertex: expected VALUE [FILE]"},
+		{"too many arguments", testRoads, []string{"info", "a", "b"}, exitUsage, "", "too many arguments: b"},
+		{"result format", testRoads, []string{"info", "--format", "xml"}, exitUsage, "", "unknown result format 'xml'"},
+		{"unknown vertex", testRoads, []string{"sssp", "--from", "Z"}, exitFailure, "", "Z"},
+		{"malformed graph", "TYPE: UNDIRECTED\nA B C\n", []string{"info"}, exitFailure, "", "graph info: <input>:2:"},
+		{"missing file", "", []string{"info", filepath.Join(t.TempDir(), "missing.txt")}, exitFailure, "", "graph info:"},
+		{"workspace", "", []string{"info", workspace}, exitFailure, "", graph.ErrWorkspace.Error()},
+		{"directed mst", testNegativeCycle, []string{"mst"}, exitFailure, "", "only works for undirected graphs"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			code, stdout, stderr := run(t, tt.stdin, tt.args...)
+			if code != tt.code {
+				t.Errorf("exit code %d, want %d\nstdout:\n%s\nstderr:\n%s", code, tt.code, stdout, stderr)
+			}
+			if !strings.Contains(stdout, tt.stdout) {
+				t.Errorf("stdout %q does not contain %q", stdout, tt.stdout)
+			}
+			if !strings.Contains(stderr, tt.stderr) {
+				t.Errorf("stderr %q does not contain %q", stderr, tt.stderr)
+			}
+			if tt.code == exitOK && stderr != "" {
+				t.Errorf("stderr of a successful command: %q", stderr)
+			}
+		})
+	}
+}
+
+func TestCommandWritesGraph(t *testing.T) {
+	code, stdout, stderr := run(t, testRoads, "add-edge", "--weight", "3", "C", "D")
+	if code != exitOK {
+		t.Fatalf("exit code %d: %s", code, stderr)
+	}
+	if !strings.Contains(stdout, "C-D: 3") {
+		t.Fatalf("the edge is not in the graph written:\n%s", stdout)
+	}
+
+	// -o chooses the format by the extension and writes nothing to the standard output
+	output := filepath.Join(t.TempDir(), "roads.dot")
+	code, stdout, stderr = run(t, testRoads, "add-vertex", "E", "-o", output)
+	if code != exitOK || stdout != "" {
+		t.Fatalf("exit code %d, stdout %q, stderr %q", code, stdout, stderr)
+	}
+	g, err := graph.ReadFile(output, "")
+	if err != nil {
+		t.Fatalf("read %s: %v", output, err)
+	}
+	if len(g.Nodes()) != 5 || graph.CountEdges(g) != 2 || !g.IsWeighted() {
+		t.Fatalf("got %d vertices, %d edges, weighted %v", len(g.Nodes()), graph.CountEdges(g), g.IsWeighted())
+	}
+
+	code, _, stderr = run(t, testRoads, "convert", "-o", filepath.Join(t.TempDir(), "missing", "roads.txt"))
+	if code != exitFailure || !strings.Contains(stderr, "graph convert: error creating file") {
+		t.Fatalf("exit code %d, stderr %q", code, stderr)
+	}
+}
+
+func TestCommandDraw(t *testing.T) {
+	draw := filepath.Join(t.TempDir(), "mst.svg")
+	code, _, stderr := run(t, testRoads, "mst", "--draw", draw)
+	if code != exitNotFound {
+		t.Fatalf("exit code %d: %s", code, stderr)
+	}
+	data, err := os.ReadFile(draw)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !strings.Contains(string(data), "<svg") {
+		t.Fatalf("not an SVG:\n%s", data)
+	}
+}
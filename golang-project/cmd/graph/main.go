@@ -0,0 +1,19 @@
+package main
+
+import (
+	"bufio"
+	"os"
+)
+
+func main() {
+	// With arguments the program runs a command and exits, without them it is interactive
+	if len(os.Args) > 1 {
+		stdout := bufio.NewWriter(os.Stdout)
+		code := runCommand(os.Args[1:], &commandEnv{stdin: os.Stdin, stdout: stdout, stderr: os.Stderr})
+		stdout.Flush()
+		os.Exit(code)
+	}
+
+	cli := NewCLI()
+	cli.Run()
+}
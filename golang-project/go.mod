@@ -0,0 +1,3 @@
+module github.com/NomenConservandum/graph-theory/golang-project
+
+go 1.22
@@ -0,0 +1,136 @@
+package graph
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"unicode"
+)
+
+// Attributes are free-form key/value metadata of a vertex or an edge:
+// labels, colors, coordinates, costs etc. The zero value is an empty set of attributes
+type Attributes map[string]string
+
+// Set sets the attribute key to value
+func (a *Attributes) Set(key string, value string) {
+	if *a == nil {
+		*a = make(Attributes)
+	}
+	(*a)[key] = value
+}
+
+// Get returns the value of the attribute key and whether it is set
+func (a Attributes) Get(key string) (string, bool) {
+	value, ok := a[key]
+	return value, ok
+}
+
+// Delete removes the attribute key
+func (a Attributes) Delete(key string) {
+	delete(a, key)
+}
+
+// Keys returns the names of the attributes in sorted order
+func (a Attributes) Keys() []string {
+	keys := make([]string, 0, len(a))
+	for key := range a {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+// String returns the attributes in the text format: "[key=value, key2="quoted value"]".
+// The keys and the values that can not be read back as they are are quoted
+func (a Attributes) String() string {
+	parts := make([]string, 0, len(a))
+	for _, key := range a.Keys() {
+		parts = append(parts, quoteAttributeKey(key)+"="+quoteAttributeValue(a[key]))
+	}
+	return "[" + strings.Join(parts, ", ") + "]"
+}
+
+// quoteAttributeValue quotes the value if it can not be read back as it is
+func quoteAttributeValue(value string) string {
+	if value == "" || strings.ContainsAny(value, ",[]=\"\\") || strings.TrimSpace(value) != value || !isPrintable(value) {
+		return strconv.Quote(value)
+	}
+	return value
+}
+
+// quoteAttributeKey quotes the key if it can not be read back as it is
+func quoteAttributeKey(key string) string {
+	if key == "" || strings.ContainsAny(key, ",[]=\"\\") || strings.IndexFunc(key, unicode.IsSpace) != -1 || !isPrintable(key) {
+		return strconv.Quote(key)
+	}
+	return key
+}
+
+// isPrintable reports whether s has no control or other non-printable characters, e.g. a line break
+func isPrintable(s string) bool {
+	for _, r := range s {
+		if !unicode.IsPrint(r) {
+			return false
+		}
+	}
+	return true
+}
+
+// parseAttributes reads "key=value, key2="quoted value", "quoted key"=value" (the inside of an attribute block)
+func parseAttributes(s string) (Attributes, error) {
+	attributes := make(Attributes)
+	rest := strings.TrimSpace(s)
+
+	for rest != "" {
+		var key string
+		if strings.HasPrefix(rest, "\"") {
+			quoted, err := strconv.QuotedPrefix(rest)
+			if err != nil {
+				return nil, fmt.Errorf("invalid quoted attribute name")
+			}
+			key, _ = strconv.Unquote(quoted)
+			rest = strings.TrimSpace(rest[len(quoted):])
+			if !strings.HasPrefix(rest, "=") {
+				return nil, fmt.Errorf("attribute '%s' has no value", key)
+			}
+			rest = rest[1:]
+		} else {
+			eq := strings.Index(rest, "=")
+			if eq == -1 {
+				return nil, fmt.Errorf("attribute '%s' has no value", rest)
+			}
+			key = strings.TrimSpace(rest[:eq])
+			if key == "" || strings.ContainsAny(key, ",\"") {
+				return nil, fmt.Errorf("invalid attribute name '%s'", key)
+			}
+			rest = rest[eq+1:]
+		}
+		rest = strings.TrimSpace(rest)
+
+		var value string
+		if strings.HasPrefix(rest, "\"") {
+			quoted, err := strconv.QuotedPrefix(rest)
+			if err != nil {
+				return nil, fmt.Errorf("invalid quoted value of attribute '%s'", key)
+			}
+			value, _ = strconv.Unquote(quoted)
+			rest = strings.TrimSpace(rest[len(quoted):])
+			if rest != "" && !strings.HasPrefix(rest, ",") {
+				return nil, fmt.Errorf("expected ',' after the value of attribute '%s'", key)
+			}
+		} else {
+			end := strings.Index(rest, ",")
+			if end == -1 {
+				end = len(rest)
+			}
+			value = strings.TrimSpace(rest[:end])
+			rest = rest[end:]
+		}
+
+		attributes[key] = value
+		rest = strings.TrimSpace(strings.TrimPrefix(rest, ","))
+	}
+
+	return attributes, nil
+}
@@ -0,0 +1,101 @@
+package graph
+
+import (
+	"fmt"
+	"io"
+	"strings"
+)
+
+// Drawing graphs as CeTZ canvases (https://cetz-package.github.io) for Typst documents.
+// The file is ready to be included in a section:
+//
+//	#figure(include "graph.typ", caption: [Минимальный каркас])
+//
+// The vertices are circles at the positions of the layout with their notes, the edges are lines or curves
+// with their weights or labels (see Diagram). Highlighted edges are drawn thick in the colors of their sets
+
+// cetzPackage is the CeTZ version imported by the drawings
+const cetzPackage = "@preview/cetz:0.3.4"
+
+// WriteCeTZ saves the graph as a CeTZ drawing to a Typst file. diagram may be nil
+func WriteCeTZ(graph *GraphInfo, path string, diagram *Diagram) error {
+	return WriteFileWith(path, func(w io.Writer) error {
+		return EncodeCeTZ(graph, w, diagram)
+	})
+}
+
+// EncodeCeTZ writes the graph as a CeTZ drawing. diagram may be nil
+func EncodeCeTZ(graph *GraphInfo, w io.Writer, diagram *Diagram) error {
+	vertices, byNode := diagramVertices(graph, diagram)
+	radius := vertexRadius(vertices)
+	edges := diagramEdges(graph, diagram, byNode, radius)
+
+	lines := []string{
+		fmt.Sprintf("// %d vertices, %d edges", len(vertices), len(edges)),
+		fmt.Sprintf("#import %q", cetzPackage),
+		"",
+		"#cetz.canvas({",
+		"  import cetz.draw: *",
+		"",
+	}
+	for _, v := range vertices {
+		fill := "white"
+		if v.fill != "" {
+			fill = fmt.Sprintf("rgb(%q)", v.fill)
+		}
+		lines = append(lines,
+			fmt.Sprintf("  circle(%s, radius: %s, fill: %s, name: %q)", cetzPoint(v.position), formatLength(radius), fill, v.name),
+			fmt.Sprintf("  content(%q, %s)", v.name, typstString(v.label)))
+		if v.note != "" {
+			corner := Point{X: v.position.X + radius*0.7, Y: v.position.Y + radius*0.7}
+			lines = append(lines, fmt.Sprintf("  content(%s, anchor: \"south-west\", text(size: 0.8em, %s))",
+				cetzPoint(corner), typstString(v.note)))
+		}
+	}
+	if len(edges) > 0 {
+		lines = append(lines, "")
+	}
+
+	for _, e := range edges {
+		color, stroke := "black", ""
+		if c := e.color(); c != "" {
+			color, stroke = c, ", stroke: "+c+" + 1.4pt"
+		}
+		mark := ""
+		if e.edge.IsOriented() {
+			mark = fmt.Sprintf(", mark: (end: \"stealth\", fill: %s)", color)
+		}
+
+		switch {
+		case e.isLoop():
+			s, c1, c2, t := e.loopCurve(radius)
+			lines = append(lines, fmt.Sprintf("  bezier(%s, %s, %s, %s%s%s)",
+				cetzPoint(s), cetzPoint(t), cetzPoint(c1), cetzPoint(c2), stroke, mark))
+		case e.bend != 0:
+			s, c, t := e.curve(radius)
+			lines = append(lines, fmt.Sprintf("  bezier(%s, %s, %s%s%s)",
+				cetzPoint(s), cetzPoint(t), cetzPoint(c), stroke, mark))
+		default:
+			s, _, t := e.curve(radius)
+			lines = append(lines, fmt.Sprintf("  line(%s, %s%s%s)", cetzPoint(s), cetzPoint(t), stroke, mark))
+		}
+		if e.label != "" {
+			lines = append(lines, fmt.Sprintf("  content(%s, box(fill: white, inset: 1pt, %s))",
+				cetzPoint(e.labelPosition(radius)), typstString(e.label)))
+		}
+	}
+	lines = append(lines, "})")
+
+	_, err := io.WriteString(w, strings.Join(lines, "\n")+"\n")
+	return err
+}
+
+func cetzPoint(p Point) string {
+	return "(" + formatLength(p.X) + ", " + formatLength(p.Y) + ")"
+}
+
+// typstString returns s as a Typst string literal
+func typstString(s string) string {
+	s = strings.NewReplacer(`\`, `\\`, `"`, `\"`, "\n", `\n`, "\r", `\r`, "\t", `\t`).Replace(s)
+	return `"` + s + `"`
+}
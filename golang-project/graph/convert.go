@@ -0,0 +1,237 @@
+package graph
+
+import (
+	"fmt"
+	"math/big"
+	"strconv"
+	"strings"
+)
+
+// WeightPolicy is how ConvertToUndirected combines the weights of edges that become one edge,
+// e.g. 'A->B: 5' and 'B->A: 3'
+type WeightPolicy int
+
+const (
+	WeightMin WeightPolicy = iota // the lightest weight
+	WeightMax                     // the heaviest weight
+	WeightSum                     // the sum of the weights
+)
+
+// ConversionReport describes what a conversion of the graph's type changed
+type ConversionReport struct {
+	EdgesChanged int      // edges that changed their direction or weight
+	EdgesAdded   int      // new edges, e.g. reverse arcs of non-oriented edges
+	EdgesRemoved int      // edges merged into other edges
+	Details      []string // a line for every merged, added or removed edge
+}
+
+// String returns the counters of the report followed by its details, one per line
+func (r *ConversionReport) String() string {
+	lines := []string{fmt.Sprintf("%d edges changed, %d added, %d removed", r.EdgesChanged, r.EdgesAdded, r.EdgesRemoved)}
+	for _, detail := range r.Details {
+		lines = append(lines, "  "+detail)
+	}
+	return strings.Join(lines, "\n")
+}
+
+func (r *ConversionReport) addDetail(format string, args ...interface{}) {
+	r.Details = append(r.Details, fmt.Sprintf(format, args...))
+}
+
+// ConvertToUndirected makes every edge of the graph non-oriented. Unless the graph is a multigraph,
+// the edges between the same pair of vertices ('A->B' and 'B->A') are merged into one edge,
+// whose weight is chosen by policy. The merged edge keeps the id and the attributes of the first edge,
+// attributes of the others are added if they are not set yet
+func ConvertToUndirected(g *GraphInfo, policy WeightPolicy) *ConversionReport {
+	report := &ConversionReport{}
+
+	kept := make(map[[2]*Node]*Edge) // the edge every pair of vertices is merged into
+	for _, edge := range GetAllEdges(g) {
+		pair := [2]*Node{edge.List[0], edge.List[1]}
+		if pair[0].Id > pair[1].Id {
+			pair[0], pair[1] = pair[1], pair[0]
+		}
+
+		target, exists := kept[pair]
+		if !exists || g.isMultigraph {
+			kept[pair] = edge
+			if edge.IsOriented() {
+				setEdgeOriented(g, edge, false)
+				report.EdgesChanged++
+			}
+			continue
+		}
+
+		before := edgeString(g, target)
+		mergeWeights(target, edge, policy)
+		for _, key := range edge.Attributes.Keys() {
+			if _, ok := target.Attributes.Get(key); !ok {
+				target.Attributes.Set(key, edge.Attributes[key])
+			}
+		}
+		RemoveEdge(g, edge)
+		report.EdgesRemoved++
+		report.addDetail("'%s' merged into '%s', now '%s'", edgeString(g, edge), before, edgeString(g, target))
+	}
+
+	g.isOriented = false
+	g.isMixed = false
+	return report
+}
+
+// ConvertToDirected makes every non-oriented edge of the graph oriented. With bothArcs
+// the edge 'A-B' becomes two arcs 'A->B' and 'B->A' with the same weight and attributes,
+// otherwise it is oriented from List[0] to List[1], the order its ends were given in.
+// An arc that already exists in a graph that is not a multigraph is not added twice
+func ConvertToDirected(g *GraphInfo, bothArcs bool) *ConversionReport {
+	report := &ConversionReport{}
+
+	for _, edge := range GetAllEdges(g) {
+		if edge.IsOriented() {
+			continue
+		}
+		from, to := edge.List[0], edge.List[1]
+
+		if !g.isMultigraph && findArc(g, from, to) != nil {
+			RemoveEdge(g, edge)
+			report.EdgesRemoved++
+			report.addDetail("'%v-%v' removed: the arc '%v->%v' already exists", from.Value, to.Value, from.Value, to.Value)
+			continue
+		}
+		setEdgeOriented(g, edge, true)
+		report.EdgesChanged++
+
+		if !bothArcs || from == to {
+			continue
+		}
+		if !g.isMultigraph && findArc(g, to, from) != nil {
+			report.addDetail("reverse arc of '%v->%v' not added: it already exists", from.Value, to.Value)
+			continue
+		}
+		reverse, _ := addEdge(g, to, from, edge.Weight, edge.exact)
+		for _, key := range edge.Attributes.Keys() {
+			reverse.Attributes.Set(key, edge.Attributes[key])
+		}
+		report.EdgesAdded++
+		report.addDetail("added '%s' as the reverse of '%s'", edgeString(g, reverse), edgeString(g, edge))
+	}
+
+	g.isOriented = true
+	g.isMixed = false
+	return report
+}
+
+// ConvertToWeighted makes the graph weighted and gives every edge defaultWeight.
+// The weights of a typed graph are read from the text of defaultWeight
+func ConvertToWeighted(g *GraphInfo, defaultWeight float64) *ConversionReport {
+	report := &ConversionReport{}
+	if g.isWeighted {
+		return report
+	}
+
+	weight, exact, err := g.ParseWeight(strconv.FormatFloat(defaultWeight, 'g', -1, 64))
+	if err != nil {
+		weight, exact = defaultWeight, nil
+	}
+	for _, edge := range GetAllEdges(g) {
+		edge.Weight = weight
+		edge.exact = exact
+		report.EdgesChanged++
+	}
+
+	g.isWeighted = true
+	return report
+}
+
+// ConvertToUnweighted makes the graph unweighted and resets the weights of its edges to 0
+func ConvertToUnweighted(g *GraphInfo) *ConversionReport {
+	report := &ConversionReport{}
+	if !g.isWeighted {
+		return report
+	}
+
+	for _, edge := range GetAllEdges(g) {
+		if edge.Weight != 0 {
+			report.EdgesChanged++
+			report.addDetail("weight %s of '%s' dropped", formatWeight(edge), edgeString(g, edge))
+		}
+		edge.Weight = 0
+		edge.exact = nil
+	}
+
+	g.isWeighted = false
+	return report
+}
+
+// setEdgeOriented changes the direction kind of e, the edge keeps its id
+func setEdgeOriented(g *GraphInfo, e *Edge, oriented bool) {
+	if e.IsOriented() == oriented {
+		return
+	}
+	unlinkEdge(g, e)
+	e.undirected = !oriented
+	linkEdge(g, e)
+}
+
+// findArc returns an oriented edge from 'from' to 'to' or nil if there is none
+func findArc(g *GraphInfo, from *Node, to *Node) *Edge {
+	for _, edge := range g.connectionsList[from] {
+		if edge.IsOriented() && edge.List[1] == to {
+			return edge
+		}
+	}
+	return nil
+}
+
+// mergeWeights sets the weight of target to the weight of target and edge combined by policy
+func mergeWeights(target *Edge, edge *Edge, policy WeightPolicy) {
+	switch policy {
+	case WeightMin:
+		if edge.Weight < target.Weight {
+			target.Weight, target.exact = edge.Weight, edge.exact
+		}
+	case WeightMax:
+		if edge.Weight > target.Weight {
+			target.Weight, target.exact = edge.Weight, edge.exact
+		}
+	case WeightSum:
+		target.Weight += edge.Weight
+		target.exact = addExact(target.exact, edge.exact)
+	}
+}
+
+// addExact returns the sum of two typed weights, nil if they are not of the same type
+func addExact(a interface{}, b interface{}) interface{} {
+	switch x := a.(type) {
+	case int:
+		if y, ok := b.(int); ok {
+			return x + y
+		}
+	case int64:
+		if y, ok := b.(int64); ok {
+			return x + y
+		}
+	case float64:
+		if y, ok := b.(float64); ok {
+			return x + y
+		}
+	case Rational:
+		if y, ok := b.(Rational); ok {
+			return Rational{rat: new(big.Rat).Add(x.Rat(), y.Rat())}
+		}
+	}
+	return nil
+}
+
+// edgeString returns the edge as it is written in the text format: "A->B: 5" or "A-B"
+func edgeString(g *GraphInfo, e *Edge) string {
+	arrow := "-"
+	if e.IsOriented() {
+		arrow = "->"
+	}
+	s := formatValue(e.List[0]) + arrow + formatValue(e.List[1])
+	if g.isWeighted {
+		s += ": " + formatWeight(e)
+	}
+	return s
+}
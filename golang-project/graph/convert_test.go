@@ -0,0 +1,127 @@
+package graph
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestConvertToUndirected(t *testing.T) {
+	src := `TYPE: DIRECTED WEIGHTED
+VERTICES: A,B,C
+EDGES:
+A->B: 5
+B->A: 3 [road=M1]
+B->C: 2
+C->C: 1
+`
+	tests := []struct {
+		policy WeightPolicy
+		weight string
+	}{
+		{WeightMin, "A-B: 3"},
+		{WeightMax, "A-B: 5"},
+		{WeightSum, "A-B: 8"},
+	}
+
+	for _, test := range tests {
+		g := decodeString(t, src, "text")
+		report := ConvertToUndirected(g, test.policy)
+
+		want := "TYPE: UNDIRECTED WEIGHTED\nVERTICES: A,B,C\nEDGES:\n" + test.weight + " [road=M1]\nB-C: 2\nC-C: 1\n"
+		if got := encodeString(t, g, "text"); got != want {
+			t.Errorf("policy %d: got\n%s\nwant\n%s", test.policy, got, want)
+		}
+		if report.EdgesChanged != 3 || report.EdgesAdded != 0 || report.EdgesRemoved != 1 || len(report.Details) != 1 {
+			t.Errorf("policy %d: report %s", test.policy, report)
+		}
+	}
+}
+
+func TestConvertToUndirectedKeepsParallelEdges(t *testing.T) {
+	g := decodeString(t, `TYPE: DIRECTED WEIGHTED MULTIGRAPH
+VERTICES: A,B
+EDGES:
+A->B: 5
+B->A: 3
+`, "text")
+	report := ConvertToUndirected(g, WeightSum)
+	if got := CountEdges(g); got != 2 || report.EdgesRemoved != 0 {
+		t.Errorf("%d edges, report %s: the edges of a multigraph are not merged", got, report)
+	}
+}
+
+func TestConvertToUndirectedSumsTypedWeights(t *testing.T) {
+	g, err := TypedGraphDecode[string, Rational](strings.NewReader(`TYPE: DIRECTED WEIGHTED
+VERTICES: A,B
+EDGES:
+A->B: 1/3
+B->A: 1/6
+`), "text")
+	if err != nil {
+		t.Fatal(err)
+	}
+	ConvertToUndirected(g.Info(), WeightSum)
+	if got := g.Weight(GetAllEdges(g.Info())[0]); got.Rat().Cmp(NewRational(1, 2).Rat()) != 0 {
+		t.Errorf("weight %v, want 1/2", got)
+	}
+}
+
+func TestConvertToDirected(t *testing.T) {
+	src := `TYPE: DIRECTED WEIGHTED MIXED
+VERTICES: A,B,C
+EDGES:
+A->B: 1
+A-B: 2
+B-C: 3 [road=M2]
+C-C: 4
+`
+	tests := []struct {
+		bothArcs bool
+		want     string
+		report   [3]int // changed, added, removed
+	}{
+		{false, "TYPE: DIRECTED WEIGHTED\nVERTICES: A,B,C\nEDGES:\nA->B: 1\nB->C: 3 [road=M2]\nC->C: 4\n", [3]int{2, 0, 1}},
+		{true, "TYPE: DIRECTED WEIGHTED\nVERTICES: A,B,C\nEDGES:\nA->B: 1\nB->C: 3 [road=M2]\nC->B: 3 [road=M2]\nC->C: 4\n", [3]int{2, 1, 1}},
+	}
+
+	for _, test := range tests {
+		g := decodeString(t, src, "text")
+		report := ConvertToDirected(g, test.bothArcs)
+
+		if got := encodeString(t, g, "text"); got != test.want {
+			t.Errorf("both arcs %v: got\n%s\nwant\n%s", test.bothArcs, got, test.want)
+		}
+		if got := [3]int{report.EdgesChanged, report.EdgesAdded, report.EdgesRemoved}; got != test.report {
+			t.Errorf("both arcs %v: report %s, want %v", test.bothArcs, report, test.report)
+		}
+	}
+}
+
+func TestConvertWeights(t *testing.T) {
+	g := decodeString(t, `TYPE: UNDIRECTED
+VERTICES: A,B,C
+EDGES:
+A-B
+B-C
+`, "text")
+
+	report := ConvertToWeighted(g, 2.5)
+	if got, want := encodeString(t, g, "text"), "TYPE: UNDIRECTED WEIGHTED\nVERTICES: A,B,C\nEDGES:\nA-B: 2.5\nB-C: 2.5\n"; got != want {
+		t.Errorf("weighted: got\n%s\nwant\n%s", got, want)
+	}
+	if report.EdgesChanged != 2 {
+		t.Errorf("weighted: report %s", report)
+	}
+	if report := ConvertToWeighted(g, 1); report.EdgesChanged != 0 || GetAllEdges(g)[0].Weight != 2.5 {
+		t.Errorf("the weights of a weighted graph changed: report %s", report)
+	}
+
+	GetAllEdges(g)[1].Weight = 0
+	report = ConvertToUnweighted(g)
+	if got, want := encodeString(t, g, "text"), "TYPE: UNDIRECTED UNWEIGHTED\nVERTICES: A,B,C\nEDGES:\nA-B\nB-C\n"; got != want {
+		t.Errorf("unweighted: got\n%s\nwant\n%s", got, want)
+	}
+	if report.EdgesChanged != 1 || len(report.Details) != 1 || !strings.Contains(report.Details[0], "weight 2.5 of 'A-B: 2.5' dropped") {
+		t.Errorf("unweighted: report %s", report)
+	}
+}
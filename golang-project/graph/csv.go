@@ -0,0 +1,235 @@
+package graph
+
+import (
+	"bufio"
+	"encoding/csv"
+	"fmt"
+	"io"
+	"os"
+	"strconv"
+	"strings"
+)
+
+// CSVEdgeListOptions map the columns of a CSV edge list (a row per edge: source, target, weight, ...)
+// to the edges of a graph. A column is its name in the header or its number starting with 1
+type CSVEdgeListOptions struct {
+	Delimiter  rune     // ',' if not set
+	Header     bool     // the first row holds the names of the columns
+	From       string   // the column of the start of the edge
+	To         string   // the column of the end of the edge
+	Weight     string   // the column of the weight, empty for an unweighted graph
+	Attributes []string // the columns read into the attributes of the edges, "*" for all the other columns
+	Oriented   bool
+	Multigraph bool // parallel edges are added, otherwise they are rejected
+}
+
+// ReadCSVEdgeList reads a graph from the CSV edge list at path. The vertices are added as they appear
+// in the rows, an attribute is named by its column in the header or "columnN" without a header.
+// The rows that can not be read (missing fields, bad weights, duplicate edges) are rejected and
+// returned with their line and column. Returns an error if the file can not be read or the columns
+// of options do not exist
+func ReadCSVEdgeList(path string, options CSVEdgeListOptions) (*GraphInfo, []*ParseError, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return nil, nil, fmt.Errorf("Error opening file: %v", err)
+	}
+	defer file.Close()
+
+	graph := GraphEmptyConstructor()
+	rejected, err := decodeCSVEdgeList(graph, bufio.NewReader(file), path, options)
+	if err != nil {
+		return nil, nil, err
+	}
+	return graph, rejected, nil
+}
+
+// csvColumns are the indexes of the mapped columns of a CSV edge list
+type csvColumns struct {
+	from, to, weight int // weight is -1 for an unweighted graph
+	attributes       []int
+	header           []string // nil if there is no header
+}
+
+// name returns the name of a column: its name in the header or "columnN"
+func (c *csvColumns) name(column int) string {
+	if column < len(c.header) {
+		return c.header[column]
+	}
+	return "column" + strconv.Itoa(column+1)
+}
+
+// resolveCSVColumns finds the columns of options in the header, or by their numbers if there is none.
+// width is the number of fields of the first row
+func resolveCSVColumns(options CSVEdgeListOptions, header []string, width int) (*csvColumns, error) {
+	index := func(column string) (int, error) {
+		column = strings.TrimSpace(column)
+		for i, name := range header {
+			if strings.TrimSpace(name) == column {
+				return i, nil
+			}
+		}
+		for i, name := range header {
+			if strings.EqualFold(strings.TrimSpace(name), column) {
+				return i, nil
+			}
+		}
+		if n, err := strconv.Atoi(column); err == nil && n >= 1 && (header == nil || n <= len(header)) {
+			return n - 1, nil
+		}
+		if header == nil {
+			return 0, fmt.Errorf("unknown column '%s': without a header the columns are numbers starting with 1", column)
+		}
+		return 0, fmt.Errorf("unknown column '%s', the columns are: %s", column, strings.Join(header, ", "))
+	}
+
+	if strings.TrimSpace(options.From) == "" || strings.TrimSpace(options.To) == "" {
+		return nil, fmt.Errorf("the columns of the start and the end of the edges must be set")
+	}
+	columns := &csvColumns{weight: -1, header: header}
+	var err error
+	if columns.from, err = index(options.From); err != nil {
+		return nil, err
+	}
+	if columns.to, err = index(options.To); err != nil {
+		return nil, err
+	}
+	if strings.TrimSpace(options.Weight) != "" {
+		if columns.weight, err = index(options.Weight); err != nil {
+			return nil, err
+		}
+	}
+
+	used := map[int]bool{columns.from: true, columns.to: true, columns.weight: true}
+	for _, column := range options.Attributes {
+		if strings.TrimSpace(column) == "*" {
+			for i := 0; i < width; i++ {
+				if !used[i] {
+					columns.attributes = append(columns.attributes, i)
+					used[i] = true
+				}
+			}
+			continue
+		}
+		i, err := index(column)
+		if err != nil {
+			return nil, err
+		}
+		if !used[i] {
+			columns.attributes = append(columns.attributes, i)
+			used[i] = true
+		}
+	}
+	return columns, nil
+}
+
+// decodeCSVEdgeList reads a CSV edge list into graph and returns the rejected rows.
+// name is the name of the file for the errors
+func decodeCSVEdgeList(graph *GraphInfo, r io.Reader, name string, options CSVEdgeListOptions) ([]*ParseError, error) {
+	reader := csv.NewReader(r)
+	if options.Delimiter != 0 {
+		reader.Comma = options.Delimiter
+	}
+	reader.FieldsPerRecord = -1
+	reader.TrimLeadingSpace = true
+
+	graph.isOriented = options.Oriented
+	graph.isWeighted = strings.TrimSpace(options.Weight) != ""
+	graph.isMultigraph = options.Multigraph
+
+	var rejected []*ParseError
+	reject := func(line int, column int, format string, args ...interface{}) {
+		err := errorAt(column, format, args...)
+		err.Path, err.Line = name, line
+		rejected = append(rejected, err)
+	}
+
+	var columns *csvColumns
+	for first := true; ; first = false {
+		record, err := reader.Read()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			if csvErr, ok := err.(*csv.ParseError); ok {
+				reject(csvErr.Line, csvErr.Column, "%v", csvErr.Err)
+				continue
+			}
+			return nil, fmt.Errorf("%s: %v", name, err)
+		}
+		if first {
+			record[0] = strings.TrimPrefix(record[0], "\ufeff") // the byte order mark of some exports
+		}
+
+		if columns == nil {
+			var header []string
+			if options.Header {
+				header = record
+			}
+			if columns, err = resolveCSVColumns(options, header, len(record)); err != nil {
+				return nil, fmt.Errorf("%s: %v", name, err)
+			}
+			if options.Header {
+				continue
+			}
+		}
+
+		line, _ := reader.FieldPos(0)
+		needed := max(columns.from, columns.to, columns.weight) + 1
+		if len(record) < needed {
+			reject(line, 1, "expected at least %d fields, found %d", needed, len(record))
+			continue
+		}
+
+		ends := [2]interface{}{}
+		valid := true
+		for i, column := range []int{columns.from, columns.to} {
+			_, col := reader.FieldPos(column)
+			text := strings.TrimSpace(record[column])
+			if text == "" {
+				reject(line, col, "empty vertex in the column '%s'", columns.name(column))
+				valid = false
+				break
+			}
+			if ends[i], err = graph.ParseValue(text); err != nil {
+				reject(line, col, "%v", err)
+				valid = false
+				break
+			}
+		}
+		if !valid {
+			continue
+		}
+
+		var weight float64
+		var exact interface{}
+		if columns.weight >= 0 {
+			_, col := reader.FieldPos(columns.weight)
+			text := strings.TrimSpace(record[columns.weight])
+			if text == "" {
+				reject(line, col, "empty weight")
+				continue
+			}
+			if weight, exact, err = graph.ParseWeight(text); err != nil {
+				reject(line, col, "%v", err)
+				continue
+			}
+		}
+
+		var edge *Edge
+		if options.Oriented {
+			edge, err = addEdgeBetweenNodes(graph, ends[0], ends[1], weight, exact)
+		} else {
+			edge, err = addNonOrientedEdgeBetweenNodes(graph, ends[0], ends[1], weight, exact)
+		}
+		if err != nil {
+			reject(line, 1, "%v", err)
+			continue
+		}
+		for _, column := range columns.attributes {
+			if column < len(record) && strings.TrimSpace(record[column]) != "" {
+				edge.Attributes.Set(columns.name(column), strings.TrimSpace(record[column]))
+			}
+		}
+	}
+	return rejected, nil
+}
@@ -0,0 +1,130 @@
+package graph
+
+import (
+	"encoding/csv"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+// decodeCSVString reads a CSV edge list and fails the test if it can not be read
+func decodeCSVString(t *testing.T, src string, options CSVEdgeListOptions) (*GraphInfo, []*ParseError) {
+	t.Helper()
+	g := GraphEmptyConstructor()
+	rejected, err := decodeCSVEdgeList(g, strings.NewReader(src), inputName, options)
+	if err != nil {
+		t.Fatalf("decode of\n%s: %v", src, err)
+	}
+	return g, rejected
+}
+
+func TestCSVEdgeListRoundTrip(t *testing.T) {
+	g := GraphConstructor(true, true)
+	AddEdgeBetweenNodes(g, "A", "B", 2.5)
+	AddEdgeBetweenNodes(g, "New York", "A", -1)
+	AddEdgeBetweenNodes(g, "B", "New York", 0)
+	GetAllEdges(g)[0].Attributes.Set("road", "M1, the old one")
+	GetAllEdges(g)[2].Attributes.Set("road", "\"quoted\"")
+
+	// The edges written as rows of a CSV file with a header
+	var buf strings.Builder
+	writer := csv.NewWriter(&buf)
+	writer.Comma = ';'
+	writer.Write([]string{"source", "target", "cost", "road"})
+	for _, edge := range GetAllEdges(g) {
+		road, _ := edge.Attributes.Get("road")
+		writer.Write([]string{edge.List[0].Value.(string), edge.List[1].Value.(string), formatWeight(edge), road})
+	}
+	writer.Flush()
+
+	back, rejected := decodeCSVString(t, buf.String(), CSVEdgeListOptions{
+		Delimiter: ';', Header: true, From: "source", To: "Target", Weight: "cost", Attributes: []string{"*"}, Oriented: true,
+	})
+	if len(rejected) > 0 {
+		t.Fatalf("rejected rows: %v", rejected)
+	}
+	if got, want := encodeString(t, back, "text"), encodeString(t, g, "text"); got != want {
+		t.Errorf("the graph changed after a round trip through\n%s\ngot\n%s\nwant\n%s", buf.String(), got, want)
+	}
+}
+
+func TestCSVEdgeListColumnNumbers(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "edges.csv")
+	if err := os.WriteFile(path, []byte("\ufeffA,x,B\nB,y,C\nC,z,A\n"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	g, rejected, err := ReadCSVEdgeList(path, CSVEdgeListOptions{From: "1", To: "3", Attributes: []string{"2"}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(rejected) > 0 {
+		t.Fatalf("rejected rows: %v", rejected)
+	}
+	if g.IsOriented() || g.IsWeighted() {
+		t.Errorf("oriented %v, weighted %v, want an undirected unweighted graph", g.IsOriented(), g.IsWeighted())
+	}
+	if got := strings.Join(values(g.Nodes()), ","); got != "A,B,C" {
+		t.Errorf("vertices %s, want A,B,C", got)
+	}
+	if label, _ := GetAllEdges(g)[0].Attributes.Get("column2"); label != "x" {
+		t.Errorf("the attribute of the first edge is %q, want x", label)
+	}
+}
+
+func TestCSVEdgeListRejectedRows(t *testing.T) {
+	src := `from,to,weight
+A,B,1
+A,,2
+B,C
+C,A,heavy
+A,B,3
+C,A,5
+B,C,"4
+`
+	g, rejected := decodeCSVString(t, src, CSVEdgeListOptions{Header: true, From: "from", To: "to", Weight: "weight"})
+
+	want := []string{
+		"<input>:3:3: empty vertex in the column 'to'",
+		"<input>:4:1: expected at least 3 fields, found 2",
+		"<input>:5:5: invalid weight 'heavy'",
+		"<input>:6:1: There is already an edge",
+		"<input>:8:",
+	}
+	if len(rejected) != len(want) {
+		t.Fatalf("rejected %v, want %d rows", rejected, len(want))
+	}
+	for i, err := range rejected {
+		if !strings.HasPrefix(err.Error(), want[i]) {
+			t.Errorf("rejected row %d: %q, want %q", i+1, err, want[i])
+		}
+	}
+	if CountEdges(g) != 2 {
+		t.Errorf("%d edges, want A-B and C-A", CountEdges(g))
+	}
+}
+
+func TestCSVEdgeListColumnErrors(t *testing.T) {
+	tests := []struct {
+		name    string
+		options CSVEdgeListOptions
+		want    string
+	}{
+		{"no columns", CSVEdgeListOptions{Header: true}, "the columns of the start and the end of the edges must be set"},
+		{"unknown name", CSVEdgeListOptions{Header: true, From: "from", To: "dest"}, "unknown column 'dest', the columns are: from, to"},
+		{"name without a header", CSVEdgeListOptions{From: "from", To: "to"}, "unknown column 'from': without a header"},
+		{"number out of the header", CSVEdgeListOptions{Header: true, From: "1", To: "3"}, "unknown column '3'"},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			_, err := decodeCSVEdgeList(GraphEmptyConstructor(), strings.NewReader("from,to\nA,B\n"), inputName, test.options)
+			if err == nil {
+				t.Fatalf("no error")
+			}
+			if !strings.Contains(err.Error(), test.want) {
+				t.Errorf("error %q, want %q", err, test.want)
+			}
+		})
+	}
+}
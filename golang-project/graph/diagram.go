@@ -0,0 +1,351 @@
+package graph
+
+import (
+	"fmt"
+	"math"
+	"strconv"
+)
+
+// Diagram describes how the CeTZ, TikZ and SVG exporters draw a graph. A nil Diagram draws
+// the graph with AutoLayout and nothing highlighted
+type Diagram struct {
+	Layout      Layout           // the positions of the vertices, AutoLayout for the missing ones
+	Highlights  []*Highlight     // the sets of highlighted edges, drawn in highlightColors
+	EdgeLabels  map[*Edge]string // the labels of the edges instead of their weights, e.g. FlowLabels
+	VertexNotes map[*Node]string // the notes written next to the vertices, e.g. DistanceNotes
+	// Classes are named sets of vertices, e.g. "knot": Knots(g). The vertices of a class are filled
+	// in its color from classColors, the classes taken by name. Mermaid and PlantUML get them as classes
+	Classes map[string][]*Node
+}
+
+// classColors are the fills of the vertices of the classes in order, light tints of highlightColors
+var classColors = []string{"#ffd6d6", "#d6e4ff", "#d9f2d9", "#ffe8cc", "#ead6ff", "#d6f5f5"}
+
+// classNames returns the names of the classes of the diagram in order
+func (d *Diagram) classNames() []string {
+	if d == nil {
+		return nil
+	}
+	return sortedKeys(d.Classes)
+}
+
+// classColor returns the fill of the vertices of the i-th class
+func classColor(i int) string {
+	return classColors[i%len(classColors)]
+}
+
+// FlowLabels returns the labels "flow/capacity" of the edges of g for the flow found by EdmondsKarp.
+// Parallel edges are merged in the flow network, so they all have the flow and the capacity of their pair
+// of vertices. A non-oriented edge has the flow of the direction it is used in
+func FlowLabels(g *GraphInfo, result *MaxFlowResult) map[*Edge]string {
+	type pair [2]*Node
+	flows := make(map[pair]*FlowEdge, len(result.Flow))
+	for flowEdge := range result.Flow {
+		flows[pair{flowEdge.From, flowEdge.To}] = flowEdge
+	}
+	labels := make(map[*Edge]string)
+	for _, edge := range GetAllEdges(g) {
+		from, to := edge.List[0], edge.List[1]
+		flowEdge := flows[pair{from, to}]
+		if reverse := flows[pair{to, from}]; !edge.IsOriented() && reverse != nil &&
+			(flowEdge == nil || result.Flow[reverse] > result.Flow[flowEdge]) {
+			flowEdge = reverse
+		}
+		if flowEdge != nil {
+			labels[edge] = formatFloat(result.Flow[flowEdge]) + "/" + formatFloat(flowEdge.Capacity)
+		}
+	}
+	return labels
+}
+
+// DistanceNotes returns the distances found by BellmanFord as notes of the vertices:
+// ∞ for an unreachable vertex and −∞ for a vertex reachable from a negative cycle
+func DistanceNotes(result *BellmanFordResult) map[*Node]string {
+	notes := make(map[*Node]string, len(result.Distances))
+	for node, distance := range result.Distances {
+		notes[node] = formatFloat(distance)
+	}
+	for _, node := range result.NegativeCycleNodes {
+		notes[node] = "−∞"
+	}
+	return notes
+}
+
+// formatFloat returns x in the shortest form, ∞ for the infinities
+func formatFloat(x float64) string {
+	switch {
+	case math.IsInf(x, 1):
+		return "∞"
+	case math.IsInf(x, -1):
+		return "−∞"
+	}
+	return strconv.FormatFloat(x, 'g', -1, 64)
+}
+
+// highlightColors are the colors of the highlighted sets in order, the first one is red as in WriteDOT.
+// The names are the same in Typst and in LaTeX (xcolor)
+var highlightColors = []string{"red", "blue", "green", "orange", "purple", "teal"}
+
+// The sizes of a diagram in centimeters
+const (
+	bendStep       = 0.5  // the distance between the middles of parallel edges
+	loopSize       = 2.6  // the length of a loop in radii of the vertex
+	minVertexSize  = 0.35 // the radius of a vertex
+	labelCharWidth = 0.11 // the width of a character of a vertex label
+)
+
+// diagramVertex is a vertex as it is drawn
+type diagramVertex struct {
+	node     *Node
+	name     string // the name of the element: v0, v1, ...
+	label    string
+	note     string
+	classes  []string // the names of the classes of the vertex in order
+	fill     string   // the color of the first class, "" if the vertex has none
+	position Point
+}
+
+// diagramEdge is an edge as it is drawn
+type diagramEdge struct {
+	edge      *Edge
+	from, to  *diagramVertex
+	bend      float64 // the distance of the middle of the curve from the straight line, to the left of from→to
+	loop      int     // the number of the loop at the vertex, 0 for the first one
+	loopAngle float64 // the direction of a loop from the vertex in radians
+	highlight int     // the number of the highlighted set, -1 if the edge is not highlighted
+	label     string
+}
+
+// color returns the color of the highlighted set or "" if the edge is not highlighted
+func (e *diagramEdge) color() string {
+	if e.highlight < 0 {
+		return ""
+	}
+	return highlightColors[e.highlight%len(highlightColors)]
+}
+
+// diagramVertices returns the vertices of graph in the order they were added with their positions and labels:
+// the attribute "label" or the value
+func diagramVertices(graph *GraphInfo, diagram *Diagram) ([]*diagramVertex, map[*Node]*diagramVertex) {
+	var layout Layout
+	var notes map[*Node]string
+	if diagram != nil {
+		layout, notes = diagram.Layout, diagram.VertexNotes
+	}
+	var auto Layout
+	vertices := make([]*diagramVertex, 0, len(graph.nodes))
+	byNode := make(map[*Node]*diagramVertex, len(graph.nodes))
+	for i, node := range graph.nodes {
+		position, ok := layout[node]
+		if !ok {
+			if auto == nil {
+				auto = AutoLayout(graph)
+			}
+			position = auto[node]
+		}
+		label := fmt.Sprintf("%v", node.Value)
+		if l, ok := graph.NodeAttributes(node).Get("label"); ok {
+			label = l
+		}
+		v := &diagramVertex{node: node, name: "v" + strconv.Itoa(i), label: label, note: notes[node], position: position}
+		vertices = append(vertices, v)
+		byNode[node] = v
+	}
+	for i, class := range diagram.classNames() {
+		for _, node := range diagram.Classes[class] {
+			if v, ok := byNode[node]; ok && (len(v.classes) == 0 || v.classes[len(v.classes)-1] != class) {
+				if v.fill == "" {
+					v.fill = classColor(i)
+				}
+				v.classes = append(v.classes, class)
+			}
+		}
+	}
+	return vertices, byNode
+}
+
+// vertexRadius returns the radius of the circles of the vertices so that the longest label fits
+func vertexRadius(vertices []*diagramVertex) float64 {
+	radius := minVertexSize
+	for _, v := range vertices {
+		radius = math.Max(radius, float64(len([]rune(v.label)))*labelCharWidth/2+0.15)
+	}
+	return radius
+}
+
+// diagramEdges returns the edges of graph as they are drawn: parallel edges (and opposite arcs)
+// are bent apart, an edge that would cross a vertex of the radius is bent around it, loops point away
+// from the center of the drawing. An edge is labeled as set in the diagram, with its weight
+// in a weighted graph and with its attribute "label" otherwise
+func diagramEdges(graph *GraphInfo, diagram *Diagram, byNode map[*Node]*diagramVertex, radius float64) []*diagramEdge {
+	edges := GetAllEdges(graph)
+	index := make(map[*Node]int, len(graph.nodes))
+	for i, node := range graph.nodes {
+		index[node] = i
+	}
+
+	layout := make(Layout, len(byNode))
+	for node, v := range byNode {
+		layout[node] = v.position
+	}
+	center := layout.center()
+
+	// The edges between the same vertices in any direction, to bend them apart
+	groups := make(map[[2]*Node][]*diagramEdge)
+	result := make([]*diagramEdge, 0, len(edges))
+	for _, edge := range edges {
+		e := &diagramEdge{edge: edge, from: byNode[edge.List[0]], to: byNode[edge.List[1]], highlight: -1}
+		if diagram != nil {
+			for i, h := range diagram.Highlights {
+				if h.Contains(edge) {
+					e.highlight = i
+					break
+				}
+			}
+		}
+		if label, ok := diagram.edgeLabel(edge); ok {
+			e.label = label
+		} else if graph.isWeighted {
+			e.label = formatWeight(edge)
+		} else if l, ok := graph.EdgeAttributes(edge).Get("label"); ok {
+			e.label = l
+		}
+
+		key := [2]*Node{edge.List[0], edge.List[1]}
+		if index[key[0]] > index[key[1]] {
+			key[0], key[1] = key[1], key[0]
+		}
+		groups[key] = append(groups[key], e)
+		result = append(result, e)
+	}
+
+	for key, group := range groups {
+		if key[0] == key[1] {
+			angle := math.Pi / 2
+			p := byNode[key[0]].position
+			if dx, dy := p.X-center.X, p.Y-center.Y; math.Hypot(dx, dy) > 1e-9 {
+				angle = math.Atan2(dy, dx)
+			}
+			for i, e := range group {
+				e.loop, e.loopAngle = i, angle
+			}
+			continue
+		}
+		if len(group) == 1 {
+			group[0].bend = avoidVertices(group[0], graph.nodes, byNode, radius, index[key[0]])
+			continue
+		}
+		for i, e := range group {
+			e.bend = (float64(i) - float64(len(group)-1)/2) * bendStep
+			if e.edge.List[0] != key[0] {
+				e.bend = -e.bend // the same side of the line between the vertices
+			}
+		}
+	}
+	return result
+}
+
+// avoidVertices returns the bend of a straight edge that goes round the vertices it would cross, 0 if it crosses none.
+// An edge over the centers of the vertices is bent to the left or to the right by the parity of turn
+func avoidVertices(e *diagramEdge, nodes []*Node, byNode map[*Node]*diagramVertex, radius float64, turn int) float64 {
+	p, q := e.from.position, e.to.position
+	dx, dy := q.X-p.X, q.Y-p.Y
+	length := math.Hypot(dx, dy)
+	if length < 1e-9 {
+		return 0
+	}
+	clearance := radius * 1.6
+	bend := 0.0
+	for _, node := range nodes {
+		v := byNode[node]
+		if v == e.from || v == e.to {
+			continue
+		}
+		// t is the place of the vertex along the edge, side its distance to the left of the edge
+		t := ((v.position.X-p.X)*dx + (v.position.Y-p.Y)*dy) / (length * length)
+		side := ((v.position.Y-p.Y)*dx - (v.position.X-p.X)*dy) / length
+		if t <= 0 || t >= 1 || math.Abs(side) >= clearance {
+			continue
+		}
+		// A curve bent by b is 4t(1-t)b away from the straight line at t
+		needed := (clearance + math.Abs(side)) / (4 * t * (1 - t))
+		if side > 1e-9 || (math.Abs(side) <= 1e-9 && turn%2 == 1) {
+			needed = -needed // to the right, away from the vertex
+		}
+		if math.Abs(needed) > math.Abs(bend) {
+			bend = needed
+		}
+	}
+	return bend
+}
+
+// edgeLabel returns the label of the edge set in EdgeLabels
+func (d *Diagram) edgeLabel(edge *Edge) (string, bool) {
+	if d == nil {
+		return "", false
+	}
+	label, ok := d.EdgeLabels[edge]
+	return label, ok
+}
+
+// isLoop reports whether the edge is a loop
+func (e *diagramEdge) isLoop() bool {
+	return e.from == e.to
+}
+
+// formatLength returns a coordinate or a length with at most two decimals
+func formatLength(x float64) string {
+	x = math.Round(x*100) / 100
+	if x == 0 {
+		x = 0 // no "-0"
+	}
+	return strconv.FormatFloat(x, 'f', -1, 64)
+}
+
+// direction returns the unit vector at the angle in radians
+func direction(angle float64) Point {
+	return Point{X: math.Cos(angle), Y: math.Sin(angle)}
+}
+
+// along returns the point at the distance from p towards q
+func along(p Point, q Point, distance float64) Point {
+	length := math.Hypot(q.X-p.X, q.Y-p.Y)
+	if length < 1e-9 {
+		return p
+	}
+	return Point{X: p.X + (q.X-p.X)*distance/length, Y: p.Y + (q.Y-p.Y)*distance/length}
+}
+
+// curve returns the quadratic Bézier curve of an edge that is not a loop, from the border of its start
+// to the border of its end: the start, the control point and the end. A straight edge has the control point in the middle
+func (e *diagramEdge) curve(radius float64) (start Point, control Point, end Point) {
+	p, q := e.from.position, e.to.position
+	control = Point{X: (p.X + q.X) / 2, Y: (p.Y + q.Y) / 2}
+	if length := math.Hypot(q.X-p.X, q.Y-p.Y); length > 1e-9 {
+		// The middle of a quadratic curve is half way to its control point
+		control.X -= (q.Y - p.Y) / length * 2 * e.bend
+		control.Y += (q.X - p.X) / length * 2 * e.bend
+	}
+	return along(p, control, radius), control, along(q, control, radius)
+}
+
+// loopCurve returns the cubic Bézier curve of a loop: the start, the two control points and the end
+func (e *diagramEdge) loopCurve(radius float64) (start Point, control1 Point, control2 Point, end Point) {
+	p := e.from.position
+	at := func(angle float64, distance float64) Point {
+		d := direction(e.loopAngle + angle)
+		return Point{X: p.X + d.X*distance, Y: p.Y + d.Y*distance}
+	}
+	size := radius * (loopSize + float64(e.loop))
+	return at(0.5, radius), at(0.6, size), at(-0.6, size), at(-0.5, radius)
+}
+
+// labelPosition returns the middle of the drawn edge, where its label is placed
+func (e *diagramEdge) labelPosition(radius float64) Point {
+	if e.isLoop() {
+		s, c1, c2, t := e.loopCurve(radius)
+		return Point{X: (s.X+t.X)/8 + (c1.X+c2.X)*3/8, Y: (s.Y+t.Y)/8 + (c1.Y+c2.Y)*3/8}
+	}
+	s, c, t := e.curve(radius)
+	return Point{X: (s.X+t.X)/4 + c.X/2, Y: (s.Y+t.Y)/4 + c.Y/2}
+}
@@ -0,0 +1,312 @@
+package graph
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+// Reading and writing graphs in the DIMACS challenge formats: maximum flow ("p max") and shortest paths ("p sp").
+//
+//	c a comment
+//	p max 4 5         the problem, the number of vertices and of arcs
+//	n 1 s             the source (max only)
+//	n 4 t             the sink (max only)
+//	a 1 2 10          an arc from 1 to 2 with capacity (max) or length (sp) 10
+//
+// Vertices are numbered 1..N. The graph read is oriented and weighted, the source and the sink
+// are kept in the graph (see FlowTerminals), not in the attributes of the vertices. The writer keeps the values
+// of the vertices in the comments "c vertex 1 "A"" before the problem line, the reader uses them if they are there
+
+// FlowTerminals returns the source and the sink of the flow network, nil if they are not set
+func FlowTerminals(g *GraphInfo) (source *Node, sink *Node) {
+	return g.flowSource, g.flowSink
+}
+
+// SetFlowTerminals sets source and sink as the source and the sink of the flow network.
+// nil unsets them
+func SetFlowTerminals(g *GraphInfo, source *Node, sink *Node) {
+	g.flowSource, g.flowSink = source, sink
+}
+
+// ReadDIMACS reads a graph from the DIMACS max flow or shortest path file at path
+// and returns it with the source and the sink of a max flow problem, nil for a shortest path problem.
+// Returns a *ParseError if the file is not valid
+func ReadDIMACS(path string) (*GraphInfo, *Node, *Node, error) {
+	graph, err := ReadFile(path, "dimacs")
+	if err != nil {
+		return nil, nil, nil, err
+	}
+	source, sink := FlowTerminals(graph)
+	return graph, source, sink, nil
+}
+
+// WriteDIMACS saves the graph to a DIMACS file: a max flow problem if the graph has a source and a sink
+// (see FlowTerminals), a shortest path problem otherwise. A non-oriented edge is written as two arcs,
+// the edges of an unweighted graph have the weight 1
+func WriteDIMACS(graph *GraphInfo, path string) error {
+	return WriteFile(graph, path, "dimacs")
+}
+
+// dimacsFields splits a line into its fields, the columns are kept for the errors
+func dimacsFields(line string) []token {
+	var fields []token
+	start := -1
+	for i, r := range line + " " {
+		switch {
+		case !unicode.IsSpace(r) && start < 0:
+			start = i
+		case unicode.IsSpace(r) && start >= 0:
+			fields = append(fields, token{kind: tokenIdentifier, text: line[start:i], column: start + 1})
+			start = -1
+		}
+	}
+	return fields
+}
+
+// dimacsReader holds the state of decodeDIMACS
+type dimacsReader struct {
+	graph   *GraphInfo
+	problem string   // "max" or "sp", empty before the problem line
+	nodes   []*Node  // the vertices by their number - 1
+	names   []string // the values of the vertices from the "c vertex" comments
+	arcs    int      // the number of arcs declared in the problem line
+	read    int      // the number of arcs read
+}
+
+// decodeDIMACS reads a DIMACS file into graph. name is the name of the file for the errors
+func decodeDIMACS(graph *GraphInfo, r io.Reader, name string) error {
+	d := &dimacsReader{graph: graph}
+	scanner := bufio.NewScanner(r)
+	lineNumber := 0
+	for scanner.Scan() {
+		lineNumber++
+		if err := d.readLine(scanner.Text()); err != nil {
+			err.Path, err.Line = name, lineNumber
+			return err
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return fmt.Errorf("Error reading file: %v", err)
+	}
+
+	var err *ParseError
+	switch {
+	case d.problem == "":
+		err = errorAt(1, "no problem line 'p max N M' or 'p sp N M'")
+	case d.read != d.arcs:
+		err = errorAt(1, "the problem has %d arcs, found %d", d.arcs, d.read)
+	case d.problem == "max":
+		if source, sink := FlowTerminals(graph); source == nil || sink == nil {
+			err = errorAt(1, "the max flow problem needs a source 'n ID s' and a sink 'n ID t'")
+		}
+	}
+	if err != nil {
+		err.Path, err.Line = name, lineNumber
+		return err
+	}
+	return nil
+}
+
+func (d *dimacsReader) readLine(line string) *ParseError {
+	fields := dimacsFields(line)
+	if len(fields) == 0 {
+		return nil
+	}
+
+	switch first := fields[0]; first.text {
+	case "c":
+		return d.readComment(line, fields)
+	case "p":
+		return d.readProblem(fields)
+	case "n":
+		return d.readTerminal(fields)
+	case "a":
+		return d.readArc(fields)
+	default:
+		return errorAt(first.column, "unknown line '%s', expected 'c', 'p', 'n' or 'a'", first.text)
+	}
+}
+
+// readComment reads the value of a vertex from "c vertex ID "value"", other comments are skipped
+func (d *dimacsReader) readComment(line string, fields []token) *ParseError {
+	if d.problem != "" || len(fields) < 4 || fields[1].text != "vertex" {
+		return nil
+	}
+	id, err := strconv.Atoi(fields[2].text)
+	if err != nil || id < 1 {
+		return nil
+	}
+	value, err := strconv.Unquote(strings.TrimSpace(line[fields[3].column-1:]))
+	if err != nil {
+		return nil
+	}
+	for len(d.names) < id {
+		d.names = append(d.names, "")
+	}
+	d.names[id-1] = value
+	return nil
+}
+
+// readProblem reads "p max N M" / "p sp N M" and adds the N vertices
+func (d *dimacsReader) readProblem(fields []token) *ParseError {
+	if d.problem != "" {
+		return errorAt(fields[0].column, "duplicate problem line")
+	}
+	if len(fields) != 4 {
+		return errorAt(fields[0].column, "expected 'p max N M' or 'p sp N M'")
+	}
+	if fields[1].text != "max" && fields[1].text != "sp" {
+		return errorAt(fields[1].column, "unknown problem '%s', expected 'max' or 'sp'", fields[1].text)
+	}
+	n, err := strconv.Atoi(fields[2].text)
+	if err != nil || n < 0 {
+		return errorAt(fields[2].column, "invalid number of vertices '%s'", fields[2].text)
+	}
+	m, err := strconv.Atoi(fields[3].text)
+	if err != nil || m < 0 {
+		return errorAt(fields[3].column, "invalid number of arcs '%s'", fields[3].text)
+	}
+
+	d.problem, d.arcs = fields[1].text, m
+	d.graph.isOriented, d.graph.isWeighted = true, true
+	for i := 1; i <= n; i++ {
+		valueStr := strconv.Itoa(i)
+		if i <= len(d.names) && d.names[i-1] != "" {
+			valueStr = d.names[i-1]
+		}
+		value, err := d.graph.ParseValue(valueStr)
+		if err != nil {
+			return errorAt(fields[2].column, "vertex %d: %v", i, err)
+		}
+		node := NodeConstructor(value)
+		if err := AddVertex(d.graph, node); err != nil {
+			return errorAt(fields[2].column, "vertex %d: duplicate value '%s'", i, valueStr)
+		}
+		d.nodes = append(d.nodes, node)
+	}
+	return nil
+}
+
+// node returns the vertex with the number in the field
+func (d *dimacsReader) node(field token) (*Node, *ParseError) {
+	id, err := strconv.Atoi(field.text)
+	if err != nil || id < 1 || id > len(d.nodes) {
+		return nil, errorAt(field.column, "invalid vertex '%s', expected 1..%d", field.text, len(d.nodes))
+	}
+	return d.nodes[id-1], nil
+}
+
+// readTerminal reads "n ID s" / "n ID t" of a max flow problem
+func (d *dimacsReader) readTerminal(fields []token) *ParseError {
+	if d.problem != "max" {
+		return errorAt(fields[0].column, "'n' lines are only allowed after 'p max'")
+	}
+	if len(fields) != 3 {
+		return errorAt(fields[0].column, "expected 'n ID s' or 'n ID t'")
+	}
+	node, err := d.node(fields[1])
+	if err != nil {
+		return err
+	}
+
+	source, sink := FlowTerminals(d.graph)
+	switch fields[2].text {
+	case "s":
+		if source != nil {
+			return errorAt(fields[0].column, "duplicate source")
+		}
+		source = node
+	case "t":
+		if sink != nil {
+			return errorAt(fields[0].column, "duplicate sink")
+		}
+		sink = node
+	default:
+		return errorAt(fields[2].column, "unknown designation '%s', expected 's' or 't'", fields[2].text)
+	}
+	if source == sink {
+		return errorAt(fields[1].column, "the source and the sink must not be the same vertex")
+	}
+	SetFlowTerminals(d.graph, source, sink)
+	return nil
+}
+
+// readArc reads "a U V W"
+func (d *dimacsReader) readArc(fields []token) *ParseError {
+	if d.problem == "" {
+		return errorAt(fields[0].column, "an arc before the problem line")
+	}
+	if len(fields) != 4 {
+		return errorAt(fields[0].column, "expected 'a U V W'")
+	}
+	from, err := d.node(fields[1])
+	if err != nil {
+		return err
+	}
+	to, err := d.node(fields[2])
+	if err != nil {
+		return err
+	}
+	weight, exact, parseErr := d.graph.ParseWeight(fields[3].text)
+	if parseErr != nil {
+		return errorAt(fields[3].column, "%v", parseErr)
+	}
+	if d.problem == "max" && weight < 0 {
+		return errorAt(fields[3].column, "negative capacity '%s'", fields[3].text)
+	}
+
+	addImportedEdge(d.graph, from, to, weight, exact, true)
+	d.read++
+	return nil
+}
+
+// encodeDIMACS writes graph as a DIMACS max flow or shortest path problem
+func encodeDIMACS(graph *GraphInfo, w io.Writer) error {
+	numbers := make(map[*Node]int, len(graph.nodes))
+	named := false
+	for i, node := range graph.nodes {
+		numbers[node] = i + 1
+		named = named || fmt.Sprintf("%v", node.Value) != strconv.Itoa(i+1)
+	}
+
+	var arcs []string
+	for _, edge := range GetAllEdges(graph) {
+		weight := "1"
+		if graph.isWeighted {
+			weight = formatWeight(edge)
+		}
+		from, to := numbers[edge.List[0]], numbers[edge.List[1]]
+		arcs = append(arcs, fmt.Sprintf("a %d %d %s", from, to, weight))
+		if !edge.IsOriented() && from != to {
+			arcs = append(arcs, fmt.Sprintf("a %d %d %s", to, from, weight))
+		}
+	}
+
+	var lines []string
+	if named {
+		for i, node := range graph.nodes {
+			value := fmt.Sprintf("%v", node.Value)
+			if utf8.ValidString(value) {
+				lines = append(lines, fmt.Sprintf("c vertex %d %s", i+1, strconv.Quote(value)))
+			}
+		}
+	}
+	source, sink := FlowTerminals(graph)
+	if source != nil && sink != nil {
+		lines = append(lines,
+			fmt.Sprintf("p max %d %d", len(graph.nodes), len(arcs)),
+			fmt.Sprintf("n %d s", numbers[source]),
+			fmt.Sprintf("n %d t", numbers[sink]))
+	} else {
+		lines = append(lines, fmt.Sprintf("p sp %d %d", len(graph.nodes), len(arcs)))
+	}
+	lines = append(lines, arcs...)
+
+	_, err := io.WriteString(w, strings.Join(lines, "\n")+"\n")
+	return err
+}
@@ -0,0 +1,116 @@
+package graph
+
+import (
+	"strings"
+	"testing"
+)
+
+const dimacsMaxFlow = `c a max flow problem
+c vertex 1 "s"
+c vertex 2 "a"
+c vertex 3 "b"
+c vertex 4 "t"
+p max 4 5
+n 1 s
+n 4 t
+a 1 2 3
+a 1 3 2
+a 2 3 1
+a 2 4 2
+a 3 4 3
+`
+
+func TestDIMACSRoundTrip(t *testing.T) {
+	tests := []struct {
+		name   string
+		dimacs string
+	}{
+		{"max flow", dimacsMaxFlow},
+		{"shortest paths", `p sp 3 3
+a 1 2 4
+a 2 3 -1.5
+a 3 1 0
+`},
+		{"parallel arcs", `p sp 2 2
+a 1 2 1
+a 1 2 2
+`},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			g := decodeString(t, test.dimacs, "dimacs")
+			back := decodeString(t, encodeString(t, g, "dimacs"), "dimacs")
+			assertSameGraph(t, g, back)
+
+			source, sink := FlowTerminals(g)
+			backSource, backSink := FlowTerminals(back)
+			if (source == nil) != (backSource == nil) || (sink == nil) != (backSink == nil) ||
+				(source != nil && (source.Value != backSource.Value || sink.Value != backSink.Value)) {
+				t.Errorf("the terminals are %v, %v, want %v, %v", backSource, backSink, source, sink)
+			}
+		})
+	}
+}
+
+func TestDIMACSTerminals(t *testing.T) {
+	g := decodeString(t, dimacsMaxFlow, "dimacs")
+	source, sink := FlowTerminals(g)
+	if source == nil || source.Value != "s" || sink == nil || sink.Value != "t" {
+		t.Fatalf("the terminals are %v, %v, want s, t", source, sink)
+	}
+
+	// The terminals are not attributes, so the other formats do not write them as such
+	for _, node := range g.Nodes() {
+		if len(node.Attributes) != 0 {
+			t.Errorf("vertex %v has the attributes %v", node.Value, node.Attributes)
+		}
+	}
+	if dot := encodeString(t, g, "dot"); strings.Contains(dot, "flow") {
+		t.Errorf("the DOT file has the terminals:\n%s", dot)
+	}
+
+	// JSON keeps them
+	back := decodeString(t, encodeString(t, g, "json"), "json")
+	if source, sink := FlowTerminals(back); source == nil || source.Value != "s" || sink == nil || sink.Value != "t" {
+		t.Errorf("the terminals after JSON are %v, %v, want s, t", source, sink)
+	}
+
+	// A removed vertex is not a terminal any more
+	RemoveVertex(g, source)
+	if source, _ := FlowTerminals(g); source != nil {
+		t.Errorf("the removed source %v is still the source", source.Value)
+	}
+}
+
+func TestDIMACSDecodeErrors(t *testing.T) {
+	tests := []struct {
+		name   string
+		dimacs string
+		want   string
+	}{
+		{"no problem line", "c only a comment\n", "no problem line"},
+		{"arc before the problem", "a 1 2 3\np sp 2 1\n", "an arc before the problem line"},
+		{"unknown problem", "p min 2 1\n", "unknown problem 'min'"},
+		{"unknown line", "p sp 2 1\nx 1 2\n", "unknown line 'x'"},
+		{"vertex out of range", "p sp 2 1\na 1 3 1\n", "invalid vertex '3', expected 1..2"},
+		{"wrong number of arcs", "p sp 2 2\na 1 2 1\n", "the problem has 2 arcs, found 1"},
+		{"no sink", "p max 2 1\nn 1 s\na 1 2 1\n", "needs a source"},
+		{"source is the sink", "p max 2 0\nn 1 s\nn 1 t\n", "must not be the same vertex"},
+		{"negative capacity", "p max 2 1\nn 1 s\nn 2 t\na 1 2 -1\n", "negative capacity"},
+		{"terminal in a shortest path problem", "p sp 2 0\nn 1 s\n", "only allowed after 'p max'"},
+		{"invalid weight", "p sp 2 1\na 1 2 x\n", "invalid weight 'x'"},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			_, err := Decode(strings.NewReader(test.dimacs), "dimacs")
+			if err == nil {
+				t.Fatalf("no error")
+			}
+			if !strings.Contains(err.Error(), test.want) {
+				t.Errorf("error %q, want %q", err, test.want)
+			}
+		})
+	}
+}
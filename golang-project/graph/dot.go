@@ -0,0 +1,817 @@
+package graph
+
+import (
+	"fmt"
+	"io"
+	"regexp"
+	"strings"
+)
+
+// Reading and writing graphs in the Graphviz DOT language (https://graphviz.org/doc/info/lang.html).
+// The reader supports graph / digraph / strict graphs, node and edge statements, chains of edges
+// (A -> B -> C), default node and edge attributes, subgraphs (their statements are read as if they
+// were in the graph, a subgraph as an end of an edge connects all its vertices: {A B} -> C)
+// and comments. Ports of node ids are ignored. A repeated edge of a strict graph adds its attributes to the first one.
+//
+// The weight of an edge is its "weight" attribute or, if there is none, its "label" when it is a number.
+// The graph attribute weighted=false, set before the edges, keeps both of them as attributes of an unweighted graph.
+// In a digraph an edge with dir=none is non-oriented and makes the graph mixed
+
+// ReadDOT reads a graph from the Graphviz DOT file at path.
+// Returns a *ParseError if the file is not valid DOT
+func ReadDOT(path string) (*GraphInfo, error) {
+	return ReadFile(path, "dot")
+}
+
+// WriteDOT saves the graph to a Graphviz DOT file. Vertex and edge attributes are written as DOT attributes,
+// the weight as the label of the edge (or as its "weight" if the edge has its own label).
+// The edges in highlight are drawn bold red
+func WriteDOT(graph *GraphInfo, path string, highlight *Highlight) error {
+	return WriteFileWith(path, func(w io.Writer) error {
+		return EncodeDOT(graph, w, highlight)
+	})
+}
+
+// EncodeDOT writes the graph in the DOT language with the edges in highlight bold red. highlight may be nil.
+// An undirected graph with oriented edges is written as a digraph with dir=none on its non-oriented edges
+func EncodeDOT(graph *GraphInfo, w io.Writer, highlight *Highlight) error {
+	oriented := graph.isOriented || hasEdgesAgainstType(graph)
+	keyword, edgeOp := "graph", "--"
+	if oriented {
+		keyword, edgeOp = "digraph", "->"
+	}
+
+	lines := []string{keyword + " {"}
+	// Otherwise the reader would take a "weight" or a numeric "label" of an unweighted graph for a weight
+	if !graph.isWeighted && hasDOTWeights(graph) {
+		lines = append(lines, "\tgraph [weighted=\"false\"];")
+	}
+	if len(graph.nodeDefaults) > 0 {
+		lines = append(lines, "\tnode"+dotAttributes(graph.nodeDefaults)+";")
+	}
+	if len(graph.edgeDefaults) > 0 {
+		lines = append(lines, "\tedge"+dotAttributes(graph.edgeDefaults)+";")
+	}
+	for _, node := range graph.nodes {
+		lines = append(lines, "\t"+dotQuote(fmt.Sprintf("%v", node.Value))+dotAttributes(node.Attributes)+";")
+	}
+
+	for _, edge := range GetAllEdges(graph) {
+		attributes := make(Attributes)
+		for _, key := range edge.Attributes.Keys() {
+			attributes.Set(key, edge.Attributes[key])
+		}
+		if graph.isWeighted {
+			if _, ok := graph.EdgeAttributes(edge).Get("label"); ok {
+				attributes.Set("weight", formatWeight(edge))
+			} else {
+				attributes.Set("label", formatWeight(edge))
+			}
+		}
+		if oriented && !edge.IsOriented() {
+			attributes.Set("dir", "none")
+		}
+		if highlight.Contains(edge) {
+			attributes.Set("color", "red")
+			attributes.Set("penwidth", "2")
+		}
+
+		lines = append(lines, fmt.Sprintf("\t%s %s %s%s;",
+			dotQuote(fmt.Sprintf("%v", edge.List[0].Value)), edgeOp,
+			dotQuote(fmt.Sprintf("%v", edge.List[1].Value)), dotAttributes(attributes)))
+	}
+	lines = append(lines, "}")
+
+	_, err := io.WriteString(w, strings.Join(lines, "\n")+"\n")
+	return err
+}
+
+// hasDOTWeights reports whether an edge of the graph, or the edge defaults, have an attribute
+// that the reader takes for a weight: "weight" or a "label" that is a number
+func hasDOTWeights(graph *GraphInfo) bool {
+	isWeight := func(attributes Attributes) bool {
+		if _, ok := attributes.Get("weight"); ok {
+			return true
+		}
+		label, ok := attributes.Get("label")
+		if !ok {
+			return false
+		}
+		_, _, err := graph.ParseWeight(label)
+		return err == nil
+	}
+
+	if isWeight(graph.edgeDefaults) {
+		return true
+	}
+	for _, edge := range graph.edgesById {
+		if isWeight(edge.Attributes) {
+			return true
+		}
+	}
+	return false
+}
+
+// dotIdentifier matches the DOT ids that can be written without quotes
+var dotIdentifier = regexp.MustCompile(`^[A-Za-z_][A-Za-z_0-9]*$`)
+
+// dotQuote returns s as a quoted DOT id
+func dotQuote(s string) string {
+	s = strings.ReplaceAll(s, `\`, `\\`)
+	s = strings.ReplaceAll(s, `"`, `\"`)
+	return `"` + s + `"`
+}
+
+// dotAttributes returns the attribute list " [key="value", ...]" or "" if there are no attributes
+func dotAttributes(attributes Attributes) string {
+	if len(attributes) == 0 {
+		return ""
+	}
+	parts := make([]string, 0, len(attributes))
+	for _, key := range attributes.Keys() {
+		name := key
+		if !dotIdentifier.MatchString(name) {
+			name = dotQuote(name)
+		}
+		parts = append(parts, name+"="+dotQuote(attributes[key]))
+	}
+	return " [" + strings.Join(parts, ", ") + "]"
+}
+
+// dotToken is a token of a DOT file: an id (quoted or not), an edge operator or a punctuation character
+type dotToken struct {
+	text   string
+	quoted bool // quoted and HTML ids are never keywords
+	isID   bool
+	line   int
+	column int
+}
+
+// tokenizeDOT splits a DOT file into tokens, the last token is an empty one at the end of the file
+func tokenizeDOT(src string) ([]dotToken, error) {
+	var tokens []dotToken
+	line, lineStart := 1, 0
+	pos := 0
+
+	errorAtPos := func(format string, args ...interface{}) error {
+		return &ParseError{Line: line, Column: pos - lineStart + 1, Msg: fmt.Sprintf(format, args...)}
+	}
+	// skipTo moves past the end marker, counting the lines
+	skipTo := func(end string) bool {
+		i := strings.Index(src[pos:], end)
+		if i == -1 {
+			return false
+		}
+		for stop := pos + i + len(end); pos < stop; pos++ {
+			if src[pos] == '\n' {
+				line++
+				lineStart = pos + 1
+			}
+		}
+		return true
+	}
+
+	for pos < len(src) {
+		c := src[pos]
+		column := pos - lineStart + 1
+		rest := src[pos:]
+
+		switch {
+		case c == '\n':
+			pos++
+			line++
+			lineStart = pos
+		case c == ' ' || c == '\t' || c == '\r':
+			pos++
+		case c == '#' && strings.TrimSpace(src[lineStart:pos]) == "":
+			// A line starting with '#' is output of the C preprocessor
+			end := strings.IndexByte(rest, '\n')
+			if end == -1 {
+				end = len(rest)
+			}
+			pos += end
+		case strings.HasPrefix(rest, "//"):
+			end := strings.IndexByte(rest, '\n')
+			if end == -1 {
+				end = len(rest)
+			}
+			pos += end
+		case strings.HasPrefix(rest, "/*"):
+			start := pos
+			pos += 2
+			if !skipTo("*/") {
+				pos = start
+				return nil, errorAtPos("unterminated comment")
+			}
+		case strings.HasPrefix(rest, "->") || strings.HasPrefix(rest, "--"):
+			tokens = append(tokens, dotToken{text: rest[:2], line: line, column: column})
+			pos += 2
+		case strings.IndexByte("{}[]=;,:+", c) != -1:
+			tokens = append(tokens, dotToken{text: rest[:1], line: line, column: column})
+			pos++
+		case c == '"':
+			startLine := line
+			var text strings.Builder
+			pos++
+			for {
+				if pos >= len(src) {
+					return nil, &ParseError{Line: startLine, Column: column, Msg: "unterminated quoted string"}
+				}
+				if src[pos] == '\\' && pos+1 < len(src) {
+					switch src[pos+1] {
+					case '"', '\\':
+						text.WriteByte(src[pos+1])
+						pos += 2
+						continue
+					case '\n':
+						// a line continuation
+						pos += 2
+						line++
+						lineStart = pos
+						continue
+					}
+				}
+				if src[pos] == '"' {
+					pos++
+					break
+				}
+				if src[pos] == '\n' {
+					line++
+					lineStart = pos + 1
+				}
+				text.WriteByte(src[pos])
+				pos++
+			}
+			tokens = append(tokens, dotToken{text: text.String(), quoted: true, isID: true, line: startLine, column: column})
+		case c == '<':
+			// An HTML string, its text is the inside of the outer brackets
+			startLine := line
+			depth := 0
+			start := pos
+			for ; pos < len(src); pos++ {
+				if src[pos] == '<' {
+					depth++
+				} else if src[pos] == '>' {
+					depth--
+				} else if src[pos] == '\n' {
+					line++
+					lineStart = pos + 1
+				}
+				if depth == 0 {
+					break
+				}
+			}
+			if depth != 0 {
+				return nil, &ParseError{Line: startLine, Column: column, Msg: "unterminated HTML string"}
+			}
+			pos++
+			tokens = append(tokens, dotToken{text: src[start+1 : pos-1], quoted: true, isID: true, line: startLine, column: column})
+		case c == '-' || c == '.' || (c >= '0' && c <= '9'):
+			// A numeral: [-]?(.[0-9]+ | [0-9]+(.[0-9]*)?)
+			end := 1
+			for end < len(rest) && (rest[end] == '.' || (rest[end] >= '0' && rest[end] <= '9')) {
+				end++
+			}
+			tokens = append(tokens, dotToken{text: rest[:end], isID: true, line: line, column: column})
+			pos += end
+		case c == '_' || c >= 0x80 || (c >= 'a' && c <= 'z') || (c >= 'A' && c <= 'Z'):
+			end := 1
+			for end < len(rest) {
+				b := rest[end]
+				if b != '_' && b < 0x80 && !(b >= 'a' && b <= 'z') && !(b >= 'A' && b <= 'Z') && !(b >= '0' && b <= '9') {
+					break
+				}
+				end++
+			}
+			tokens = append(tokens, dotToken{text: rest[:end], isID: true, line: line, column: column})
+			pos += end
+		default:
+			return nil, errorAtPos("unexpected '%c'", c)
+		}
+	}
+
+	tokens = append(tokens, dotToken{line: line, column: pos - lineStart + 1})
+	return tokens, nil
+}
+
+// dotParser reads the statements of a DOT graph into a GraphInfo
+type dotParser struct {
+	tokens       []dotToken
+	pos          int
+	graph        *GraphInfo
+	strict       bool
+	hasWeights   bool
+	unweighted   bool          // set by the graph attribute weighted=false: the weights are kept as attributes
+	nodeDefaults Attributes    // set by "node [...]" statements
+	edgeDefaults Attributes    // set by "edge [...]" statements
+	subgraphs    []*dotNodeSet // the vertices of the subgraphs being read, the innermost last
+}
+
+// dotNodeSet is the vertices of a subgraph in the order they are met
+type dotNodeSet struct {
+	nodes []*Node
+	seen  map[*Node]bool
+}
+
+// decodeDOT reads a graph in the DOT language into graph. name is the name of the file for the errors
+func decodeDOT(graph *GraphInfo, r io.Reader, name string) error {
+	src, err := io.ReadAll(r)
+	if err != nil {
+		return fmt.Errorf("Error reading file: %v", err)
+	}
+
+	err = func() error {
+		tokens, err := tokenizeDOT(string(src))
+		if err != nil {
+			return err
+		}
+		p := &dotParser{tokens: tokens, graph: graph}
+		return p.parseGraph()
+	}()
+
+	if parseErr, ok := err.(*ParseError); ok {
+		parseErr.Path = name
+	}
+	return err
+}
+
+func (p *dotParser) peek() dotToken {
+	return p.tokens[p.pos]
+}
+
+func (p *dotParser) next() dotToken {
+	tok := p.tokens[p.pos]
+	if p.pos < len(p.tokens)-1 {
+		p.pos++
+	}
+	return tok
+}
+
+// isKeyword reports whether tok is the DOT keyword word. Keywords are case-independent
+func isKeyword(tok dotToken, word string) bool {
+	return tok.isID && !tok.quoted && strings.EqualFold(tok.text, word)
+}
+
+func (p *dotParser) errorAt(tok dotToken, format string, args ...interface{}) error {
+	return &ParseError{Line: tok.line, Column: tok.column, Msg: fmt.Sprintf(format, args...)}
+}
+
+// expect reads the punctuation token text
+func (p *dotParser) expect(text string) error {
+	tok := p.next()
+	if tok.isID || tok.text != text {
+		return p.errorAt(tok, "expected '%s', got '%s'", text, tok.text)
+	}
+	return nil
+}
+
+// id reads an id, joining quoted strings concatenated with '+'
+func (p *dotParser) id() (dotToken, error) {
+	tok := p.next()
+	if !tok.isID {
+		return tok, p.errorAt(tok, "expected an id, got '%s'", tok.text)
+	}
+	for tok.quoted && p.peek().text == "+" && !p.peek().isID {
+		p.next()
+		part := p.next()
+		if !part.quoted {
+			return tok, p.errorAt(part, "expected a quoted string after '+'")
+		}
+		tok.text += part.text
+	}
+	return tok, nil
+}
+
+// parseGraph reads "[strict] (graph | digraph) [id] { statements }"
+func (p *dotParser) parseGraph() error {
+	tok := p.next()
+	if isKeyword(tok, "strict") {
+		p.strict = true
+		tok = p.next()
+	}
+	switch {
+	case isKeyword(tok, "graph"):
+		p.graph.isOriented = false
+	case isKeyword(tok, "digraph"):
+		p.graph.isOriented = true
+	default:
+		return p.errorAt(tok, "expected 'graph' or 'digraph', got '%s'", tok.text)
+	}
+	if p.peek().isID {
+		p.next() // the name of the graph
+	}
+
+	// Edges are added as weighted, whether the graph is weighted is known at the end
+	p.graph.isWeighted = true
+	if err := p.expect("{"); err != nil {
+		return err
+	}
+	if err := p.parseStatements(); err != nil {
+		return err
+	}
+	if end := p.next(); end.text != "" || end.isID {
+		return p.errorAt(end, "unexpected '%s' after the end of the graph", end.text)
+	}
+	p.graph.isWeighted = p.hasWeights
+	p.keepDefaults()
+	return nil
+}
+
+// keepDefaults makes the default attributes of the graph the defaults of the graph, so that the vertices
+// and the edges keep only the attributes that differ from them. The weight and the direction of the edges
+// are not attributes, so they are taken out of the defaults as they are out of the edges
+func (p *dotParser) keepDefaults() {
+	edgeDefaults := copyAttributes(p.edgeDefaults)
+	if !p.unweighted {
+		edgeDefaults.Delete("weight")
+		if label, ok := edgeDefaults.Get("label"); ok {
+			if _, _, err := p.graph.ParseWeight(label); err == nil {
+				edgeDefaults.Delete("label")
+			}
+		}
+	}
+	if dir, _ := edgeDefaults.Get("dir"); dir == "none" && p.graph.isOriented {
+		edgeDefaults.Delete("dir")
+	}
+
+	p.graph.nodeDefaults, p.graph.edgeDefaults = copyAttributes(p.nodeDefaults), copyAttributes(edgeDefaults)
+	for _, node := range p.graph.nodes {
+		node.Attributes = withoutDefaults(node.Attributes, p.graph.nodeDefaults)
+	}
+	for _, edge := range GetAllEdges(p.graph) {
+		edge.Attributes = withoutDefaults(edge.Attributes, p.graph.edgeDefaults)
+	}
+}
+
+// parseStatements reads statements up to and including the closing '}'
+func (p *dotParser) parseStatements() error {
+	for {
+		tok := p.peek()
+		switch {
+		case !tok.isID && tok.text == "}":
+			p.next()
+			return nil
+		case !tok.isID && tok.text == ";":
+			p.next()
+		case !tok.isID && tok.text == "":
+			return p.errorAt(tok, "expected '}' at the end of the graph")
+		default:
+			if err := p.parseStatement(); err != nil {
+				return err
+			}
+		}
+	}
+}
+
+func (p *dotParser) parseStatement() error {
+	tok := p.peek()
+
+	// Attribute statements: default attributes of the following nodes / edges
+	for _, keyword := range []string{"graph", "node", "edge"} {
+		if isKeyword(tok, keyword) {
+			p.next()
+			attributes, err := p.parseAttributeLists()
+			if err != nil {
+				return err
+			}
+			for _, key := range attributes.Keys() {
+				switch keyword {
+				case "graph":
+					p.graphAttribute(key, attributes[key])
+				case "node":
+					p.nodeDefaults.Set(key, attributes[key])
+				case "edge":
+					p.edgeDefaults.Set(key, attributes[key])
+				}
+			}
+			return nil
+		}
+	}
+
+	// An edge statement: "A -> B -> C [attributes]", an end can be a subgraph: "{A B} -> C"
+	var first []*Node
+	if isSubgraphStart(tok) {
+		nodes, err := p.parseSubgraph()
+		if err != nil || !isEdgeOperator(p.peek()) {
+			return err
+		}
+		first = nodes
+	} else {
+		id, err := p.nodeID()
+		if err != nil {
+			return err
+		}
+
+		// A graph attribute: "rankdir = LR"
+		if next := p.peek(); !next.isID && next.text == "=" {
+			p.next()
+			value, err := p.id()
+			if err == nil {
+				p.graphAttribute(id.text, value.text)
+			}
+			return err
+		}
+		if !isEdgeOperator(p.peek()) {
+			return p.parseNodeStatement(id)
+		}
+		node, err := p.node(id)
+		if err != nil {
+			return err
+		}
+		first = []*Node{node}
+	}
+
+	ends := [][]*Node{first}
+	for isEdgeOperator(p.peek()) {
+		op := p.next()
+		if (op.text == "->") != p.graph.isOriented {
+			return p.errorAt(op, "edge operator '%s' in a %s", op.text, map[bool]string{true: "digraph", false: "graph"}[p.graph.isOriented])
+		}
+		end, err := p.edgeEnd()
+		if err != nil {
+			return err
+		}
+		ends = append(ends, end)
+	}
+	listStart := p.peek()
+	attributes, err := p.parseAttributeLists()
+	if err != nil {
+		return err
+	}
+	for i := 1; i < len(ends); i++ {
+		for _, from := range ends[i-1] {
+			for _, to := range ends[i] {
+				if err := p.addEdge(from, to, attributes, listStart); err != nil {
+					return err
+				}
+			}
+		}
+	}
+	return nil
+}
+
+// graphAttribute reads a graph attribute. Only weighted=false is kept, the others are not
+func (p *dotParser) graphAttribute(key string, value string) {
+	if key == "weighted" {
+		p.unweighted = strings.EqualFold(value, "false")
+	}
+}
+
+// parseNodeStatement reads the rest of a node statement: "A [attributes]"
+func (p *dotParser) parseNodeStatement(id dotToken) error {
+	attributes, err := p.parseAttributeLists()
+	if err != nil {
+		return err
+	}
+	node, err := p.node(id)
+	if err != nil {
+		return err
+	}
+	for _, key := range attributes.Keys() {
+		node.Attributes.Set(key, attributes[key])
+	}
+	return nil
+}
+
+// edgeEnd reads the end of an edge after an edge operator: a vertex or a subgraph with all its vertices
+func (p *dotParser) edgeEnd() ([]*Node, error) {
+	if isSubgraphStart(p.peek()) {
+		return p.parseSubgraph()
+	}
+	id, err := p.nodeID()
+	if err != nil {
+		return nil, err
+	}
+	node, err := p.node(id)
+	if err != nil {
+		return nil, err
+	}
+	return []*Node{node}, nil
+}
+
+// isSubgraphStart reports whether tok starts a subgraph: "subgraph" or "{"
+func isSubgraphStart(tok dotToken) bool {
+	return isKeyword(tok, "subgraph") || (!tok.isID && tok.text == "{")
+}
+
+// isEdgeOperator reports whether tok is "->" or "--"
+func isEdgeOperator(tok dotToken) bool {
+	return !tok.isID && (tok.text == "->" || tok.text == "--")
+}
+
+// parseSubgraph reads "[subgraph [id]] { statements }" and returns the vertices used in it.
+// The default attributes set inside the subgraph are only used inside of it
+func (p *dotParser) parseSubgraph() ([]*Node, error) {
+	if isKeyword(p.peek(), "subgraph") {
+		p.next()
+		if p.peek().isID {
+			p.next()
+		}
+	}
+	if err := p.expect("{"); err != nil {
+		return nil, err
+	}
+
+	set := &dotNodeSet{seen: make(map[*Node]bool)}
+	p.subgraphs = append(p.subgraphs, set)
+	nodeDefaults, edgeDefaults := p.nodeDefaults, p.edgeDefaults
+	p.nodeDefaults = copyAttributes(nodeDefaults)
+	p.edgeDefaults = copyAttributes(edgeDefaults)
+	err := p.parseStatements()
+	p.nodeDefaults, p.edgeDefaults = nodeDefaults, edgeDefaults
+	p.subgraphs = p.subgraphs[:len(p.subgraphs)-1]
+	if err != nil {
+		return nil, err
+	}
+	return set.nodes, nil
+}
+
+// nodeID reads "id [: port [: compass]]", the port is ignored
+func (p *dotParser) nodeID() (dotToken, error) {
+	tok, err := p.id()
+	if err != nil {
+		return tok, err
+	}
+	for i := 0; i < 2; i++ {
+		if next := p.peek(); next.isID || next.text != ":" {
+			break
+		}
+		p.next()
+		if _, err := p.id(); err != nil {
+			return tok, err
+		}
+	}
+	return tok, nil
+}
+
+// parseAttributeLists reads zero or more "[key = value, key2 = value2; ...]"
+func (p *dotParser) parseAttributeLists() (Attributes, error) {
+	attributes := make(Attributes)
+	for next := p.peek(); !next.isID && next.text == "["; next = p.peek() {
+		p.next()
+		for {
+			tok := p.peek()
+			if !tok.isID && tok.text == "]" {
+				p.next()
+				break
+			}
+			key, err := p.id()
+			if err != nil {
+				return nil, err
+			}
+			if err := p.expect("="); err != nil {
+				return nil, err
+			}
+			value, err := p.id()
+			if err != nil {
+				return nil, err
+			}
+			attributes[key.text] = value.text
+			if separator := p.peek(); !separator.isID && (separator.text == "," || separator.text == ";") {
+				p.next()
+			}
+		}
+	}
+	return attributes, nil
+}
+
+// node returns the vertex with the given id, adding it with the default node attributes if it is new.
+// The vertex becomes a vertex of the subgraphs being read
+func (p *dotParser) node(tok dotToken) (*Node, error) {
+	value, err := p.graph.ParseValue(tok.text)
+	if err != nil {
+		return nil, p.errorAt(tok, "%v", err)
+	}
+	node := FindNodeByValue(p.graph, value)
+	if node == nil {
+		node = NodeConstructor(value)
+		node.Attributes = copyAttributes(p.nodeDefaults)
+		AddVertex(p.graph, node)
+	}
+	for _, set := range p.subgraphs {
+		if !set.seen[node] {
+			set.seen[node] = true
+			set.nodes = append(set.nodes, node)
+		}
+	}
+	return node, nil
+}
+
+// addEdge adds the edge from 'from' to 'to' with the default edge attributes and the given ones.
+// In a strict graph the attributes of a repeated edge are merged into the existing one.
+// listStart is the start of the attribute list, for the errors of the weight
+func (p *dotParser) addEdge(from *Node, to *Node, attributes Attributes, listStart dotToken) error {
+	edgeAttributes := copyAttributes(p.edgeDefaults)
+	for _, key := range attributes.Keys() {
+		edgeAttributes.Set(key, attributes[key])
+	}
+	weight, exact, _, err := p.edgeWeight(edgeAttributes, listStart)
+	if err != nil {
+		return err
+	}
+
+	oriented := p.graph.isOriented
+	if dir, ok := edgeAttributes.Get("dir"); ok && oriented && dir == "none" {
+		oriented = false
+		p.graph.isMixed = true
+		edgeAttributes.Delete("dir")
+	}
+
+	var edge *Edge
+	if p.strict {
+		if edge, err = addOrientedOrNot(p.graph, from, to, weight, exact, oriented); err != nil {
+			return p.mergeEdge(findEdge(p.graph, from, to, oriented), attributes, listStart)
+		}
+	} else {
+		// A graph that is not strict can have parallel edges
+		edge = addImportedEdge(p.graph, from, to, weight, exact, oriented)
+	}
+	if len(edgeAttributes) > 0 {
+		edge.Attributes = edgeAttributes
+	}
+	return nil
+}
+
+// mergeEdge sets the attributes of a repeated edge of a strict graph, and its weight if they have one
+func (p *dotParser) mergeEdge(edge *Edge, attributes Attributes, listStart dotToken) error {
+	attributes = copyAttributes(attributes)
+	weight, exact, hasWeight, err := p.edgeWeight(attributes, listStart)
+	if err != nil {
+		return err
+	}
+	if hasWeight {
+		edge.Weight, edge.exact = weight, exact
+	}
+	attributes.Delete("dir")
+	for _, key := range attributes.Keys() {
+		edge.Attributes.Set(key, attributes[key])
+	}
+	return nil
+}
+
+// edgeWeight takes the weight of an edge out of its attributes: the "weight" attribute or a label that is a number.
+// Reports whether there is a weight
+func (p *dotParser) edgeWeight(attributes Attributes, listStart dotToken) (float64, interface{}, bool, error) {
+	if p.unweighted {
+		return 0, nil, false, nil
+	}
+	if weightStr, ok := attributes.Get("weight"); ok {
+		weight, exact, err := p.graph.ParseWeight(weightStr)
+		if err != nil {
+			return 0, nil, false, p.errorAt(listStart, "%v", err)
+		}
+		attributes.Delete("weight")
+		p.hasWeights = true
+		return weight, exact, true, nil
+	}
+	if label, ok := attributes.Get("label"); ok {
+		if weight, exact, err := p.graph.ParseWeight(label); err == nil {
+			attributes.Delete("label")
+			p.hasWeights = true
+			return weight, exact, true, nil
+		}
+	}
+	return 0, nil, false, nil
+}
+
+// findEdge returns the edge from 'from' to 'to', or a non-oriented one between them, nil if there is none
+func findEdge(g *GraphInfo, from *Node, to *Node, oriented bool) *Edge {
+	for _, edge := range g.connectionsList[from] {
+		if edge.IsOriented() == oriented && edge.Other(from) == to && (!oriented || edge.List[0] == from) {
+			return edge
+		}
+	}
+	return nil
+}
+
+// withoutDefaults returns the attributes that differ from the defaults. A default that is not in the attributes
+// is set to an empty value: the element was added before the default was set or outside of its subgraph
+func withoutDefaults(attributes Attributes, defaults Attributes) Attributes {
+	if len(defaults) == 0 {
+		return attributes
+	}
+	var result Attributes
+	for key, value := range attributes {
+		if defaultValue, ok := defaults[key]; !ok || defaultValue != value {
+			result.Set(key, value)
+		}
+	}
+	for key := range defaults {
+		if _, ok := attributes[key]; !ok {
+			result.Set(key, "")
+		}
+	}
+	return result
+}
+
+// copyAttributes returns a copy of attributes, nil if there are none
+func copyAttributes(attributes Attributes) Attributes {
+	if len(attributes) == 0 {
+		return nil
+	}
+	result := make(Attributes, len(attributes))
+	for key, value := range attributes {
+		result[key] = value
+	}
+	return result
+}
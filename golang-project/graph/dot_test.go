@@ -0,0 +1,224 @@
+package graph
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+)
+
+// decodeString reads a graph in the format from src and fails the test if it can not be read
+func decodeString(t *testing.T, src string, format string) *GraphInfo {
+	t.Helper()
+	g, err := Decode(strings.NewReader(src), format)
+	if err != nil {
+		t.Fatalf("decode of\n%s: %v", src, err)
+	}
+	return g
+}
+
+// encodeString writes the graph in the format and fails the test if it can not be written
+func encodeString(t *testing.T, g *GraphInfo, format string) string {
+	t.Helper()
+	var buf bytes.Buffer
+	if err := Encode(g, &buf, format); err != nil {
+		t.Fatalf("encode: %v", err)
+	}
+	return buf.String()
+}
+
+func TestDOTDecode(t *testing.T) {
+	tests := []struct {
+		name string
+		dot  string
+		want string // the graph in the text format
+	}{
+		{"strict merge", `strict digraph { a -> b; a -> b [weight=3, color=red]; b -> a }`, `TYPE: DIRECTED WEIGHTED
+VERTICES: a,b
+EDGES:
+a->b: 3 [color=red]
+b->a: 0
+`},
+		{"parallel edges", `digraph { a -> b; a -> b [color=red] }`, `TYPE: DIRECTED UNWEIGHTED MULTI
+VERTICES: a,b
+EDGES:
+a->b
+a->b [color=red]
+`},
+		{"chain", `digraph { a -> b -> c [label=2] }`, `TYPE: DIRECTED WEIGHTED
+VERTICES: a,b,c
+EDGES:
+a->b: 2
+b->c: 2
+`},
+		{"subgraph endpoints", `graph { {a b} -- c; d -- subgraph s { e f } }`, `TYPE: UNDIRECTED UNWEIGHTED
+VERTICES: a,b,c,d,e,f
+EDGES:
+a-c
+b-c
+d-e
+d-f
+`},
+		{"quoted and HTML ids", `digraph { "New York" -> "a\"b"; "x" + "y" -> <<b>bold</b>> }`, `TYPE: DIRECTED UNWEIGHTED
+VERTICES: "New York","a\"b",xy,"<b>bold</b>"
+EDGES:
+"New York"->"a\"b"
+xy->"<b>bold</b>"
+`},
+		{"dir none", `digraph { a -> b [dir=none]; b -> c }`, `TYPE: MIXED UNWEIGHTED
+VERTICES: a,b,c
+EDGES:
+a-b
+b->c
+`},
+		{"comments and ports", `/* a comment */ digraph G {
+# a line of the preprocessor
+	a:p1:n -> b; // a comment
+	rankdir = LR
+}`, `TYPE: DIRECTED UNWEIGHTED
+VERTICES: a,b
+EDGES:
+a->b
+`},
+		{"label that is not a number", `graph { a -- b [label=road] }`, `TYPE: UNDIRECTED UNWEIGHTED
+VERTICES: a,b
+EDGES:
+a-b [label=road]
+`},
+		{"weighted=false", `graph { weighted=false; a -- b [label=5, weight=2] }`, `TYPE: UNDIRECTED UNWEIGHTED
+VERTICES: a,b
+EDGES:
+a-b [label=5, weight=2]
+`},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			g := decodeString(t, test.dot, "dot")
+			if got := encodeString(t, g, "text"); got != test.want {
+				t.Errorf("got\n%s\nwant\n%s", got, test.want)
+			}
+		})
+	}
+}
+
+func TestDOTDefaults(t *testing.T) {
+	g := decodeString(t, `digraph {
+	node [shape=box];
+	edge [color=blue];
+	a;
+	a -> b [color=""];
+	subgraph { node [shape=circle]; c }
+	c -> a
+}`, "dot")
+
+	if shape, _ := g.NodeDefaults().Get("shape"); shape != "box" {
+		t.Errorf("the default shape is %q, want box", shape)
+	}
+	if color, _ := g.EdgeDefaults().Get("color"); color != "blue" {
+		t.Errorf("the default color is %q, want blue", color)
+	}
+
+	wantShapes := map[string]string{"a": "box", "b": "box", "c": "circle"}
+	for value, want := range wantShapes {
+		node := FindNodeByValue(g, value)
+		if shape, _ := g.NodeAttributes(node).Get("shape"); shape != want {
+			t.Errorf("vertex %s: shape %q, want %q", value, shape, want)
+		}
+	}
+	if len(FindNodeByValue(g, "a").Attributes) != 0 {
+		t.Errorf("vertex a keeps the default as its own attribute: %v", FindNodeByValue(g, "a").Attributes)
+	}
+
+	edges := GetAllEdges(g)
+	if color, ok := g.EdgeAttributes(edges[0]).Get("color"); ok {
+		t.Errorf("edge a->b: the empty color does not unset the default, got %q", color)
+	}
+	if color, _ := g.EdgeAttributes(edges[1]).Get("color"); color != "blue" {
+		t.Errorf("edge c->a: color %q, want blue", color)
+	}
+
+	// The defaults are written as defaults and read back the same
+	back := decodeString(t, encodeString(t, g, "dot"), "dot")
+	if got, want := encodeString(t, back, "text"), encodeString(t, g, "text"); got != want {
+		t.Errorf("the graph changed after a round trip:\n%s\nwant\n%s", got, want)
+	}
+}
+
+func TestDOTDecodeErrors(t *testing.T) {
+	tests := []struct {
+		name string
+		dot  string
+		want string
+	}{
+		{"undirected operator in a digraph", `digraph { a -- b }`, "edge operator '--' in a digraph"},
+		{"directed operator in a graph", `graph { a -> b }`, "edge operator '->' in a graph"},
+		{"no graph keyword", `{ a -> b }`, "expected 'graph' or 'digraph'"},
+		{"missing end of the edge", `digraph { a -> }`, "expected an id"},
+		{"missing brace", `digraph { a -> b`, "expected '}'"},
+		{"unterminated string", `digraph { "a -> b }`, "unterminated quoted string"},
+		{"unterminated comment", `digraph { a /* b }`, "unterminated comment"},
+		{"invalid weight", `digraph { a -> b [weight=x] }`, "invalid weight 'x'"},
+		{"text after the graph", `digraph { a } b`, "after the end of the graph"},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			_, err := Decode(strings.NewReader(test.dot), "dot")
+			if err == nil {
+				t.Fatalf("no error")
+			}
+			if !strings.Contains(err.Error(), test.want) {
+				t.Errorf("error %q, want %q", err, test.want)
+			}
+		})
+	}
+}
+
+func TestDOTRoundTrip(t *testing.T) {
+	weighted := MultigraphConstructor(true, true)
+	AddEdgeBetweenNodes(weighted, "A", "B", 2.5)
+	AddEdgeBetweenNodes(weighted, "A", "B", -1)
+	AddEdgeBetweenNodes(weighted, "New York", "A", 0)
+	weighted.Nodes()[0].Attributes.Set("color", "red")
+	GetAllEdges(weighted)[0].Attributes.Set("label", "road")
+	GetAllEdges(weighted)[1].Attributes.Set("odd key", "a \"quoted\" \\ value")
+
+	// A numeric label of an unweighted graph is not a weight
+	labels := GraphConstructor(false, false)
+	AddNonOrientedEdgeBetweenNodes(labels, "A", "B", 0)
+	AddNonOrientedEdgeBetweenNodes(labels, "B", "C", 0)
+	GetAllEdges(labels)[0].Attributes.Set("label", "5")
+	GetAllEdges(labels)[1].Attributes.Set("weight", "1")
+
+	// An oriented edge of an undirected graph keeps its direction
+	against := GraphConstructor(false, true)
+	AddNonOrientedEdgeBetweenNodes(against, "A", "B", 1)
+	AddEdgeBetweenNodes(against, "B", "C", 2)
+
+	mixed := GraphConstructor(true, false)
+	mixed.SetMixed(true)
+	AddEdgeBetweenNodes(mixed, "A", "B", 0)
+	AddNonOrientedEdgeBetweenNodes(mixed, "B", "C", 0)
+	mixed.NodeDefaults().Set("shape", "box")
+	mixed.EdgeDefaults().Set("label", "7")
+
+	tests := []struct {
+		name string
+		g    *GraphInfo
+	}{
+		{"weighted multigraph", weighted},
+		{"numeric labels of an unweighted graph", labels},
+		{"oriented edge of an undirected graph", against},
+		{"mixed with defaults", mixed},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			encoded := encodeString(t, test.g, "dot")
+			back := decodeString(t, encoded, "dot")
+			if got, want := encodeString(t, back, "text"), encodeString(t, test.g, "text"); got != want {
+				t.Errorf("the graph changed after a round trip through\n%s\ngot\n%s\nwant\n%s", encoded, got, want)
+			}
+		})
+	}
+}
@@ -0,0 +1,418 @@
+package graph
+
+import (
+	"bufio"
+	"bytes"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strings"
+	"sync"
+)
+
+// The registry of graph file formats. A format is found by its name ("dot"), by the extension
+// of a file (".gv") or by the beginning of the content. Decode and Encode work with any io.Reader
+// and io.Writer (stdin, a network connection, a bytes.Buffer), ReadFile and WriteFile with files.
+// The built-in formats are text, dot, graphml, gexf, json, dimacs, adjacency and incidence,
+// and cetz, tikz, svg, mermaid and plantuml that can only be written; more can be added with RegisterFormat
+
+// Format is a graph file format
+type Format struct {
+	Name       string   // the name used by Decode and Encode
+	Extensions []string // the extensions of the files, with the dot: ".gv"
+	// Decode reads a graph from r into the empty graph. name is the name of the input for the errors.
+	// nil if the format can not be read
+	Decode func(graph *GraphInfo, r io.Reader, name string) error
+	// Encode writes graph to w. nil if the format can not be written
+	Encode func(graph *GraphInfo, w io.Writer) error
+	// Detect reports whether head, the first bytes of the input, is in the format.
+	// nil if the format can not be detected
+	Detect func(head []byte) bool
+}
+
+// detectSize is the number of bytes given to Format.Detect
+const detectSize = 4096
+
+// inputName is the name of the input of Decode in the errors
+const inputName = "<input>"
+
+var (
+	formatsMu sync.RWMutex
+	formats   = []*Format{
+		{
+			Name:       "text",
+			Extensions: []string{".txt"},
+			Decode: func(graph *GraphInfo, r io.Reader, name string) error {
+				_, err := decodeText(graph, r, name, false)
+				return err
+			},
+			Encode: encodeText,
+			Detect: detectText,
+		},
+		{
+			Name:       "dot",
+			Extensions: []string{".dot", ".gv"},
+			Decode:     decodeDOT,
+			Encode: func(graph *GraphInfo, w io.Writer) error {
+				return EncodeDOT(graph, w, nil)
+			},
+			Detect: detectDOT,
+		},
+		{
+			Name:       "graphml",
+			Extensions: []string{".graphml"},
+			Decode:     decodeGraphML,
+			Encode:     encodeGraphML,
+			Detect:     detectXML("graphml"),
+		},
+		{
+			Name:       "gexf",
+			Extensions: []string{".gexf"},
+			Decode:     decodeGEXF,
+			Encode:     encodeGEXF,
+			Detect:     detectXML("gexf"),
+		},
+		{
+			Name:       "json",
+			Extensions: []string{".json"},
+			Decode:     decodeJSON,
+			Encode:     encodeJSON,
+			Detect:     detectJSON,
+		},
+		{
+			Name:       "dimacs",
+			Extensions: []string{".max", ".sp", ".gr", ".dimacs"},
+			Decode:     decodeDIMACS,
+			Encode:     encodeDIMACS,
+			Detect:     detectDIMACS,
+		},
+		{
+			Name:       "cetz",
+			Extensions: []string{".typ"},
+			Encode: func(graph *GraphInfo, w io.Writer) error {
+				return EncodeCeTZ(graph, w, nil)
+			},
+		},
+		{
+			Name:       "tikz",
+			Extensions: []string{".tikz", ".tex"},
+			Encode: func(graph *GraphInfo, w io.Writer) error {
+				return EncodeTikZ(graph, w, nil)
+			},
+		},
+		{
+			Name:       "svg",
+			Extensions: []string{".svg"},
+			Encode: func(graph *GraphInfo, w io.Writer) error {
+				return EncodeSVG(graph, w, nil)
+			},
+		},
+		{
+			Name:       "mermaid",
+			Extensions: []string{".mmd", ".mermaid"},
+			Encode: func(graph *GraphInfo, w io.Writer) error {
+				return EncodeMermaid(graph, w, nil)
+			},
+		},
+		{
+			Name:       "plantuml",
+			Extensions: []string{".puml", ".plantuml"},
+			Encode: func(graph *GraphInfo, w io.Writer) error {
+				return EncodePlantUML(graph, w, nil)
+			},
+		},
+		// The matrices are read as oriented and weighted, so that any matrix can be read,
+		// and written with the values of the vertices. A missing edge of a weighted graph is written as INF,
+		// so that it differs from the weight 0, and 0 is read as a weight in a matrix with INF or empty cells.
+		// Use AdjacencyMatrixConstructor for other options
+		{
+			Name: "adjacency",
+			Decode: func(graph *GraphInfo, r io.Reader, name string) error {
+				src, err := io.ReadAll(r)
+				if err != nil {
+					return fmt.Errorf("Error reading file: %v", err)
+				}
+				options := MatrixOptions{Oriented: true, Weighted: true}
+				if hasNoEdgeCells(src) {
+					options.NoEdge = NoEdgeInf
+				}
+				return decodeAdjacencyMatrix(graph, bytes.NewReader(src), name, options)
+			},
+			Encode: func(graph *GraphInfo, w io.Writer) error {
+				options := MatrixOptions{Header: true}
+				if graph.isWeighted {
+					options.NoEdge = NoEdgeInf
+				}
+				return EncodeAdjacencyMatrix(graph, w, options)
+			},
+		},
+		{
+			Name: "incidence",
+			Decode: func(graph *GraphInfo, r io.Reader, name string) error {
+				return decodeIncidenceMatrix(graph, r, name, MatrixOptions{Oriented: true, Weighted: true})
+			},
+			Encode: func(graph *GraphInfo, w io.Writer) error {
+				return EncodeIncidenceMatrix(graph, w, MatrixOptions{Header: true})
+			},
+		},
+	}
+)
+
+// RegisterFormat adds a format to the registry. Returns an error if the name or an extension is already taken
+func RegisterFormat(format *Format) error {
+	if format.Name == "" {
+		return fmt.Errorf("the format has no name")
+	}
+	formatsMu.Lock()
+	defer formatsMu.Unlock()
+
+	for _, registered := range formats {
+		if registered.Name == format.Name {
+			return fmt.Errorf("the format '%s' is already registered", format.Name)
+		}
+		for _, ext := range format.Extensions {
+			if hasExtension(registered, ext) {
+				return fmt.Errorf("the extension '%s' is already used by the format '%s'", ext, registered.Name)
+			}
+		}
+	}
+	formats = append(formats, format)
+	return nil
+}
+
+// Formats returns the registered formats in the order they were registered
+func Formats() []*Format {
+	formatsMu.RLock()
+	defer formatsMu.RUnlock()
+	return append([]*Format(nil), formats...)
+}
+
+// FormatNames returns the sorted names of the registered formats
+func FormatNames() []string {
+	var names []string
+	for _, format := range Formats() {
+		names = append(names, format.Name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// FormatByName returns the format with the name or nil if there is none
+func FormatByName(name string) *Format {
+	for _, format := range Formats() {
+		if format.Name == name {
+			return format
+		}
+	}
+	return nil
+}
+
+// FormatByExtension returns the format of the file at path by its extension or nil if it is unknown
+func FormatByExtension(path string) *Format {
+	ext := filepath.Ext(path)
+	if ext == "" {
+		return nil
+	}
+	for _, format := range Formats() {
+		if hasExtension(format, ext) {
+			return format
+		}
+	}
+	return nil
+}
+
+func hasExtension(format *Format, ext string) bool {
+	for _, e := range format.Extensions {
+		if strings.EqualFold(e, ext) {
+			return true
+		}
+	}
+	return false
+}
+
+// DetectFormat returns the first format whose Detect accepts head, the beginning of a file, or nil
+func DetectFormat(head []byte) *Format {
+	for _, format := range Formats() {
+		if format.Detect != nil && format.Detect(head) {
+			return format
+		}
+	}
+	return nil
+}
+
+// FileFormat returns the format of the file at path: by its extension or, if it is unknown,
+// by its content. The text format if neither is known, ErrWorkspace if the file is a workspace
+func FileFormat(path string) (*Format, error) {
+	if format := FormatByExtension(path); format != nil {
+		return format, nil
+	}
+	file, err := os.Open(path)
+	if err != nil {
+		return nil, fmt.Errorf("Error opening file: %v", err)
+	}
+	defer file.Close()
+
+	head := make([]byte, detectSize)
+	n, err := io.ReadFull(file, head)
+	if err != nil && err != io.EOF && err != io.ErrUnexpectedEOF {
+		return nil, fmt.Errorf("Error reading file: %v", err)
+	}
+	if detectWorkspace(head[:n]) {
+		return nil, fmt.Errorf("%s: %w", path, ErrWorkspace)
+	}
+	if format := DetectFormat(head[:n]); format != nil {
+		return format, nil
+	}
+	return FormatByName("text"), nil
+}
+
+// Decode reads a graph in the named format from r. An empty format is detected from the content,
+// the text format is read if it can not be detected
+func Decode(r io.Reader, format string) (*GraphInfo, error) {
+	graph := GraphEmptyConstructor()
+	if err := decodeInto(graph, r, format, inputName); err != nil {
+		return nil, err
+	}
+	return graph, nil
+}
+
+// decodeInto reads r in the named or detected format into graph. name is the name of the input for the errors
+func decodeInto(graph *GraphInfo, r io.Reader, format string, name string) error {
+	var f *Format
+	if format == "" {
+		buffered := bufio.NewReaderSize(r, detectSize)
+		head, _ := buffered.Peek(detectSize)
+		if detectWorkspace(head) {
+			return fmt.Errorf("%s: %w", name, ErrWorkspace)
+		}
+		if f = DetectFormat(head); f == nil {
+			f = FormatByName("text")
+		}
+		r = buffered
+	} else if f = FormatByName(format); f == nil {
+		return unknownFormat(format)
+	}
+
+	if f.Decode == nil {
+		return fmt.Errorf("the format '%s' can not be read", f.Name)
+	}
+	return f.Decode(graph, r, name)
+}
+
+// Encode writes the graph to w in the named format
+func Encode(graph *GraphInfo, w io.Writer, format string) error {
+	f, err := encoderOf(format)
+	if err != nil {
+		return err
+	}
+	return f.Encode(graph, w)
+}
+
+// encoderOf returns the named format if it can be written
+func encoderOf(format string) (*Format, error) {
+	f := FormatByName(format)
+	if f == nil {
+		return nil, unknownFormat(format)
+	}
+	if f.Encode == nil {
+		return nil, fmt.Errorf("the format '%s' can not be written", f.Name)
+	}
+	return f, nil
+}
+
+// ReadFile reads a graph from the file at path in the named format. An empty format is chosen
+// by FileFormat. Returns a *ParseError if the file is not valid
+func ReadFile(path string, format string) (*GraphInfo, error) {
+	if format == "" {
+		f, err := FileFormat(path)
+		if err != nil {
+			return nil, err
+		}
+		format = f.Name
+	}
+	return readFileWith(path, func(graph *GraphInfo, r io.Reader, name string) error {
+		return decodeInto(graph, r, format, name)
+	})
+}
+
+// WriteFile saves the graph to the file at path in the named format.
+// An empty format is chosen by the extension of path, the text format if it is unknown
+func WriteFile(graph *GraphInfo, path string, format string) error {
+	if format == "" {
+		format = "text"
+		if f := FormatByExtension(path); f != nil {
+			format = f.Name
+		}
+	}
+	f, err := encoderOf(format)
+	if err != nil {
+		return err
+	}
+
+	return WriteFileWith(path, func(w io.Writer) error {
+		return f.Encode(graph, w)
+	})
+}
+
+func unknownFormat(format string) error {
+	return fmt.Errorf("unknown format '%s', the formats are: %s", format, strings.Join(FormatNames(), ", "))
+}
+
+// firstLine returns the first line of head that is not empty and does not start with a comment prefix
+func firstLine(head []byte, comments ...string) string {
+	head = bytes.TrimPrefix(head, []byte("\ufeff"))
+	for _, line := range strings.Split(string(head), "\n") {
+		line = strings.TrimSpace(line)
+		if line == "" {
+			continue
+		}
+		comment := false
+		for _, prefix := range comments {
+			comment = comment || strings.HasPrefix(line, prefix)
+		}
+		if !comment {
+			return line
+		}
+	}
+	return ""
+}
+
+func detectText(head []byte) bool {
+	line := firstLine(head, "#")
+	for _, section := range []string{"TYPE:", "VERTICES:", "ATTRIBUTES:", "EDGES:"} {
+		if strings.HasPrefix(line, section) {
+			return true
+		}
+	}
+	return false
+}
+
+var (
+	dotBlockComment = regexp.MustCompile(`(?s)/\*.*?\*/`)
+	dotHeader       = regexp.MustCompile(`(?i)^(strict\s+)?(di)?graph([\s{"]|$)`)
+)
+
+func detectDOT(head []byte) bool {
+	return dotHeader.MatchString(firstLine(dotBlockComment.ReplaceAll(head, nil), "//", "#"))
+}
+
+// detectXML returns a Detect for the XML documents with the root element
+func detectXML(root string) func(head []byte) bool {
+	return func(head []byte) bool {
+		return strings.HasPrefix(firstLine(head), "<") && bytes.Contains(head, []byte("<"+root))
+	}
+}
+
+// detectJSON accepts a JSON object that is not a workspace (see detectWorkspace)
+func detectJSON(head []byte) bool {
+	return strings.HasPrefix(firstLine(head), "{") && !detectWorkspace(head)
+}
+
+var dimacsHeader = regexp.MustCompile(`^(c(\s|$)|p\s+(max|sp)\s)`)
+
+func detectDIMACS(head []byte) bool {
+	return dimacsHeader.MatchString(firstLine(head))
+}
@@ -0,0 +1,262 @@
+package graph
+
+import (
+	"encoding/xml"
+	"fmt"
+	"io"
+	"strings"
+)
+
+// Reading and writing graphs in GEXF (https://gexf.net), the format of Gephi.
+// Node and edge attributes (attvalues) are read into Attributes by their titles, a node label
+// that differs from its id is the attribute "label", as is the label of an edge.
+// Dynamics, hierarchies and visualization data (viz:*) are not read
+
+const gexfNamespace = "http://gexf.net/1.3"
+
+type gexfDocument struct {
+	XMLName xml.Name  `xml:"gexf"`
+	Xmlns   string    `xml:"xmlns,attr,omitempty"`
+	Version string    `xml:"version,attr,omitempty"`
+	Graph   gexfGraph `xml:"graph"`
+}
+
+type gexfGraph struct {
+	DefaultEdgeType string           `xml:"defaultedgetype,attr,omitempty"`
+	Mode            string           `xml:"mode,attr,omitempty"`
+	Attributes      []gexfAttributes `xml:"attributes"`
+	Nodes           []gexfNode       `xml:"nodes>node"`
+	Edges           []gexfEdge       `xml:"edges>edge"`
+}
+
+type gexfAttributes struct {
+	Class      string          `xml:"class,attr"`
+	Attributes []gexfAttribute `xml:"attribute"`
+}
+
+type gexfAttribute struct {
+	ID      string  `xml:"id,attr"`
+	Title   string  `xml:"title,attr"`
+	Type    string  `xml:"type,attr"`
+	Default *string `xml:"default,omitempty"`
+}
+
+type gexfNode struct {
+	ID        string         `xml:"id,attr"`
+	Label     string         `xml:"label,attr,omitempty"`
+	AttValues []gexfAttValue `xml:"attvalues>attvalue"`
+}
+
+type gexfEdge struct {
+	ID        string         `xml:"id,attr,omitempty"`
+	Source    string         `xml:"source,attr"`
+	Target    string         `xml:"target,attr"`
+	Type      string         `xml:"type,attr,omitempty"`
+	Label     string         `xml:"label,attr,omitempty"`
+	Weight    string         `xml:"weight,attr,omitempty"`
+	AttValues []gexfAttValue `xml:"attvalues>attvalue"`
+}
+
+type gexfAttValue struct {
+	For   string `xml:"for,attr,omitempty"`
+	ID    string `xml:"id,attr,omitempty"` // GEXF 1.1 names the attribute with id instead of for
+	Value string `xml:"value,attr"`
+}
+
+// ReadGEXF reads a graph from the GEXF file at path
+func ReadGEXF(path string) (*GraphInfo, error) {
+	return ReadFile(path, "gexf")
+}
+
+// WriteGEXF saves the graph to a GEXF 1.3 file. Attributes are declared by their values
+// (long, double, boolean or string), the weight is the weight of the edges
+func WriteGEXF(graph *GraphInfo, path string) error {
+	return WriteFile(graph, path, "gexf")
+}
+
+// decodeGEXF reads a GEXF document into graph. name is the name of the file for the errors
+func decodeGEXF(graph *GraphInfo, r io.Reader, name string) error {
+	var doc gexfDocument
+	if err := decodeXML(r, name, &doc); err != nil {
+		return err
+	}
+	g := doc.Graph
+
+	titles := map[string]map[string]string{"node": {}, "edge": {}} // the titles of the attributes by class and id
+	defaults := map[string]Attributes{"node": nil, "edge": nil}
+	for _, declared := range g.Attributes {
+		class := declared.Class
+		if _, ok := titles[class]; !ok {
+			continue
+		}
+		for _, attribute := range declared.Attributes {
+			title := attribute.Title
+			if title == "" {
+				title = attribute.ID
+			}
+			titles[class][attribute.ID] = title
+			if attribute.Default != nil {
+				attributes := defaults[class]
+				attributes.Set(title, *attribute.Default)
+				defaults[class] = attributes
+			}
+		}
+	}
+	// attributesOf returns the attributes of a node or an edge: the defaults and then the values
+	attributesOf := func(class string, values []gexfAttValue) Attributes {
+		var attributes Attributes
+		for _, key := range defaults[class].Keys() {
+			attributes.Set(key, defaults[class][key])
+		}
+		for _, value := range values {
+			id := value.For
+			if id == "" {
+				id = value.ID
+			}
+			title, ok := titles[class][id]
+			if !ok {
+				title = id
+			}
+			attributes.Set(title, value.Value)
+		}
+		return attributes
+	}
+
+	graph.isOriented = g.DefaultEdgeType != "undirected" && g.DefaultEdgeType != "mutual"
+
+	for _, n := range g.Nodes {
+		value, err := graph.ParseValue(n.ID)
+		if err != nil {
+			return fmt.Errorf("%s: node '%s': %v", name, n.ID, err)
+		}
+		node := NodeConstructor(value)
+		node.Attributes = attributesOf("node", n.AttValues)
+		if n.Label != "" && n.Label != n.ID {
+			node.Attributes.Set("label", n.Label)
+		}
+		if err := AddVertex(graph, node); err != nil {
+			return fmt.Errorf("%s: duplicate node '%s'", name, n.ID)
+		}
+	}
+
+	for _, e := range g.Edges {
+		ends := [2]*Node{}
+		for i, id := range []string{e.Source, e.Target} {
+			value, err := graph.ParseValue(id)
+			if err != nil {
+				return fmt.Errorf("%s: edge '%s': %v", name, e.ID, err)
+			}
+			if ends[i] = FindNodeByValue(graph, value); ends[i] == nil {
+				return fmt.Errorf("%s: edge '%s': unknown node '%s'", name, e.ID, id)
+			}
+		}
+
+		var weight float64
+		var exact interface{}
+		if e.Weight != "" {
+			var err error
+			if weight, exact, err = graph.ParseWeight(strings.TrimSpace(e.Weight)); err != nil {
+				return fmt.Errorf("%s: edge '%s': %v", name, e.ID, err)
+			}
+			graph.isWeighted = true
+		}
+
+		oriented := graph.isOriented
+		if e.Type != "" {
+			oriented = e.Type == "directed"
+			if oriented != graph.isOriented {
+				graph.isMixed = true
+			}
+		}
+
+		edge := addImportedEdge(graph, ends[0], ends[1], weight, exact, oriented)
+		attributes := attributesOf("edge", e.AttValues)
+		if e.Label != "" {
+			attributes.Set("label", e.Label)
+		}
+		if len(attributes) > 0 {
+			edge.Attributes = attributes
+		}
+	}
+
+	// A mixed graph is oriented, its non-oriented edges are walked both ways
+	if graph.isMixed {
+		graph.isOriented = true
+	}
+	return nil
+}
+
+// encodeGEXF writes graph as a GEXF 1.3 document
+func encodeGEXF(graph *GraphInfo, w io.Writer) error {
+	edgeType := "undirected"
+	if graph.isOriented {
+		edgeType = "directed"
+	}
+	g := gexfGraph{DefaultEdgeType: edgeType, Mode: "static"}
+
+	// The attribute "label" is written as the label of the node or the edge
+	edges := GetAllEdges(graph)
+	nodeValues, edgeValues := nodeAttributeValues(graph), edgeAttributeValues(graph, edges)
+	delete(nodeValues, "label")
+	delete(edgeValues, "label")
+	nodeIds := declareGEXFAttributes(&g, "node", nodeValues)
+	edgeIds := declareGEXFAttributes(&g, "edge", edgeValues)
+
+	for _, node := range graph.nodes {
+		id := fmt.Sprintf("%v", node.Value)
+		n := gexfNode{ID: id, Label: id}
+		attributes := graph.NodeAttributes(node)
+		for _, key := range attributes.Keys() {
+			if key == "label" {
+				n.Label = attributes[key]
+				continue
+			}
+			n.AttValues = append(n.AttValues, gexfAttValue{For: nodeIds[key], Value: attributes[key]})
+		}
+		g.Nodes = append(g.Nodes, n)
+	}
+
+	for _, edge := range edges {
+		e := gexfEdge{
+			ID:     fmt.Sprintf("%d", edge.Id),
+			Source: fmt.Sprintf("%v", edge.List[0].Value),
+			Target: fmt.Sprintf("%v", edge.List[1].Value),
+		}
+		if edge.IsOriented() != graph.isOriented {
+			e.Type = "undirected"
+			if edge.IsOriented() {
+				e.Type = "directed"
+			}
+		}
+		if graph.isWeighted {
+			e.Weight = formatWeight(edge)
+		}
+		attributes := graph.EdgeAttributes(edge)
+		for _, key := range attributes.Keys() {
+			if key == "label" {
+				e.Label = attributes[key]
+				continue
+			}
+			e.AttValues = append(e.AttValues, gexfAttValue{For: edgeIds[key], Value: attributes[key]})
+		}
+		g.Edges = append(g.Edges, e)
+	}
+
+	return encodeXML(w, gexfDocument{Xmlns: gexfNamespace, Version: "1.3", Graph: g})
+}
+
+// declareGEXFAttributes declares an attribute of the class for every attribute name
+// and returns the ids of the attributes by name
+func declareGEXFAttributes(g *gexfGraph, class string, values map[string][]string) map[string]string {
+	ids := make(map[string]string)
+	if len(values) == 0 {
+		return ids
+	}
+	declared := gexfAttributes{Class: class}
+	for i, name := range sortedKeys(values) {
+		ids[name] = fmt.Sprintf("%d", i)
+		declared.Attributes = append(declared.Attributes, gexfAttribute{ID: ids[name], Title: name, Type: attributeType(values[name])})
+	}
+	g.Attributes = append(g.Attributes, declared)
+	return ids
+}
@@ -0,0 +1,64 @@
+package graph
+
+import (
+	"testing"
+)
+
+func TestGEXFRoundTrip(t *testing.T) {
+	assertXMLRoundTrip(t, "gexf")
+}
+
+func TestGEXFDecode(t *testing.T) {
+	g := decodeString(t, `<?xml version="1.0" encoding="UTF-8"?>
+<gexf xmlns="http://gexf.net/1.3" version="1.3">
+  <graph defaultedgetype="directed">
+    <attributes class="node">
+      <attribute id="0" title="city" type="boolean"><default>true</default></attribute>
+    </attributes>
+    <nodes>
+      <node id="1" label="Paris"/>
+      <node id="2" label="2"><attvalues><attvalue for="0" value="false"/></attvalues></node>
+    </nodes>
+    <edges>
+      <edge id="0" source="1" target="2" weight="3.5" label="A1"/>
+      <edge id="1" source="2" target="2" type="undirected"/>
+    </edges>
+  </graph>
+</gexf>`, "gexf")
+
+	if !g.IsOriented() || !g.IsMixed() || !g.IsWeighted() {
+		t.Errorf("oriented %v, mixed %v, weighted %v, want a weighted mixed graph", g.IsOriented(), g.IsMixed(), g.IsWeighted())
+	}
+	paris := FindNodeByValue(g, "1")
+	if label, _ := paris.Attributes.Get("label"); label != "Paris" {
+		t.Errorf("the label of 1 is %q, want Paris", label)
+	}
+	if city, _ := paris.Attributes.Get("city"); city != "true" {
+		t.Errorf("the city of 1 is %q, want the default true", city)
+	}
+	other := FindNodeByValue(g, "2")
+	if _, ok := other.Attributes.Get("label"); ok {
+		t.Errorf("the label equal to the id is kept")
+	}
+	if city, _ := other.Attributes.Get("city"); city != "false" {
+		t.Errorf("the city of 2 is %q, want false", city)
+	}
+	edges := GetAllEdges(g)
+	if len(edges) != 2 || edges[0].Weight != 3.5 || !edges[0].IsOriented() || edges[1].IsOriented() {
+		t.Fatalf("edges %v", edges)
+	}
+	if label, _ := edges[0].Attributes.Get("label"); label != "A1" {
+		t.Errorf("the label of the edge is %q, want A1", label)
+	}
+}
+
+func TestGEXFDecodeErrors(t *testing.T) {
+	assertDecodeErrors(t, "gexf", []struct{ name, src, want string }{
+		{"syntax error", "<gexf>\n<graph>\n<nodes>\n</graph>\n</gexf>", "<input>:4:"},
+		{"duplicate node", `<gexf><graph><nodes><node id="a"/><node id="a"/></nodes></graph></gexf>`, "duplicate node 'a'"},
+		{"unknown node", `<gexf><graph><nodes><node id="a"/></nodes><edges><edge id="0" source="a" target="b"/></edges></graph></gexf>`,
+			"edge '0': unknown node 'b'"},
+		{"invalid weight", `<gexf><graph><nodes><node id="a"/></nodes><edges><edge id="0" source="a" target="a" weight="x"/></edges></graph></gexf>`,
+			"invalid weight 'x'"},
+	})
+}
@@ -0,0 +1,740 @@
+// Package graph implements directed / undirected / mixed, weighted / unweighted graphs,
+// the algorithms from tasks 2-11 and reading / writing graphs in the text format.
+package graph
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"math"
+	"os"
+	"reflect"
+	"strconv"
+	"strings"
+)
+
+// граф: ориентированный / неориентированный, взвешанный / невзвешанный
+// методы внутри и снаружи графа
+// методы чтения из файла и в файл
+// Хранить рёбра как отдельный класс в списке. Так будет легко удалять рёбра с обеих сторон (много ко многому?)
+// Ссылка на общий список! Наверное.
+// Можно использовать set.
+
+// Метод: хранить список рёбер (вес (int32?) + направление (тернарное значение)) + список вершин. У каждого ребра список ссылок на вершины.
+
+// Node is a vertex of the graph
+type Node struct {
+	Id         uint32 // assigned by AddVertex, stays the same after other vertices are removed
+	Value      interface{}
+	Attributes Attributes
+	// Connection *edges
+}
+
+// NodeEmptyConstructor returns a vertex without a value
+func NodeEmptyConstructor() *Node {
+	return &Node{}
+}
+
+// NodeConstructor returns a vertex holding value
+func NodeConstructor(value interface{}) *Node {
+	return &Node{Value: value}
+}
+
+// Edge is a connection from List[0] to List[1].
+// A non-oriented edge is a single Edge referenced from the lists of both of its ends
+type Edge struct {
+	Id   uint32   // assigned by AddEdge, stays the same after other edges are removed
+	List [2]*Node // first - from, second - to
+	// Why do we store 'from?' It is easy to remove edge this way:
+	// you don't have to walk through the whole map to find the edge
+	Weight     float64
+	Attributes Attributes
+	exact      interface{} // the weight of a typed graph (see Graph) as it was given, Weight holds it as float64
+	undirected bool        // set by AddNonOrientedEdge: the edge can be walked in both directions
+}
+
+// IsOriented reports whether the edge can only be walked from List[0] to List[1].
+// In a mixed graph oriented and non-oriented edges are stored together
+func (e *Edge) IsOriented() bool {
+	return !e.undirected
+}
+
+// EdgeConstructor returns an edge from 'from' to 'to' with the given weight
+func EdgeConstructor(from *Node, to *Node, weight float64) *Edge {
+	var E = Edge{Weight: weight}
+	E.List[0] = from
+	E.List[1] = to
+	return &E
+}
+
+// Other returns the end of the edge opposite to n. For an edge taken from the list of n
+// it is the vertex the edge leads to, whichever end of a non-oriented edge n is
+func (e *Edge) Other(n *Node) *Node {
+	if e.List[0] == n {
+		return e.List[1]
+	}
+	return e.List[0]
+}
+
+// GraphInfo is a graph: the list of its vertices and the outgoing edges of every vertex
+type GraphInfo struct {
+	nodes           []*Node // here are the nodes in the order they were added
+	connectionsList map[*Node][]*Edge
+	incomingList    map[*Node][]*Edge     // reverse of connectionsList: the edges coming into every vertex
+	nodesByValue    map[interface{}]*Node // index for FindNodeByValue, key is valueKey(node.Value)
+	nodesById       map[uint32]*Node
+	edgesById       map[uint32]*Edge
+	nextNodeId      uint32 // ids are never reused, even after the vertex / edge is removed
+	nextEdgeId      uint32
+	isOriented      bool
+	isWeighted      bool
+	isMultigraph    bool       // parallel edges between the same ordered pair of vertices are allowed
+	isMixed         bool       // the edges can be both oriented and non-oriented, isOriented is set as well
+	nodeDefaults    Attributes // the attributes of every vertex that it does not set itself, e.g. DOT "node [...]"
+	edgeDefaults    Attributes // the same for the edges, e.g. DOT "edge [...]"
+	flowSource      *Node      // the source and the sink of the flow network, e.g. of a DIMACS "p max" file
+	flowSink        *Node
+
+	// Conversion of the text of vertex values and edge weights, set by typed graphs (see Graph).
+	// nil means vertex values are strings and weights are float64
+	parseValue  func(string) (interface{}, error)
+	parseWeight func(string) (float64, interface{}, error)
+}
+
+// GraphEmptyConstructor returns an empty graph
+func GraphEmptyConstructor() *GraphInfo {
+	return &GraphInfo{
+		connectionsList: make(map[*Node][]*Edge),
+		incomingList:    make(map[*Node][]*Edge),
+		nodesByValue:    make(map[interface{}]*Node),
+		nodesById:       make(map[uint32]*Node),
+		edgesById:       make(map[uint32]*Edge),
+		nodes:           make([]*Node, 0),
+	}
+}
+
+// GraphConstructor returns an empty graph of the given type
+func GraphConstructor(isOriented bool, isWeighted bool) *GraphInfo {
+	var G = GraphInfo{
+		isOriented:      isOriented,
+		isWeighted:      isWeighted,
+		connectionsList: make(map[*Node][]*Edge), // Initialize here
+		incomingList:    make(map[*Node][]*Edge),
+		nodesByValue:    make(map[interface{}]*Node),
+		nodesById:       make(map[uint32]*Node),
+		edgesById:       make(map[uint32]*Edge),
+		nodes:           make([]*Node, 0),
+	}
+	return &G
+}
+
+// MultigraphConstructor returns an empty graph of the given type that allows parallel edges
+func MultigraphConstructor(isOriented bool, isWeighted bool) *GraphInfo {
+	var G = GraphConstructor(isOriented, isWeighted)
+	G.isMultigraph = true
+	return G
+}
+
+// Nodes returns the vertices of the graph in the order they were added
+func (g *GraphInfo) Nodes() []*Node {
+	return g.nodes
+}
+
+// OutEdges returns the edges going out of n. Non-oriented edges are both outgoing and incoming for both of their ends
+func (g *GraphInfo) OutEdges(n *Node) []*Edge {
+	return g.connectionsList[n]
+}
+
+// NodeById returns the vertex with the given id or nil if there is none
+func (g *GraphInfo) NodeById(id uint32) *Node {
+	return g.nodesById[id]
+}
+
+// EdgeById returns the edge with the given id or nil if there is none
+func (g *GraphInfo) EdgeById(id uint32) *Edge {
+	return g.edgesById[id]
+}
+
+// InEdges returns the edges coming into n
+func (g *GraphInfo) InEdges(n *Node) []*Edge {
+	return g.incomingList[n]
+}
+
+// InDegree returns the number of edges coming into n
+func (g *GraphInfo) InDegree(n *Node) int {
+	return len(g.incomingList[n])
+}
+
+// OutDegree returns the number of edges going out of n
+func (g *GraphInfo) OutDegree(n *Node) int {
+	return len(g.connectionsList[n])
+}
+
+// Predecessors returns the vertices that have an edge into n. A non-oriented edge comes into both of its ends,
+// so its other end is a predecessor of each of them
+func (g *GraphInfo) Predecessors(n *Node) []*Node {
+	predecessors := make([]*Node, 0, len(g.incomingList[n]))
+	for _, edge := range g.incomingList[n] {
+		predecessors = append(predecessors, edge.Other(n))
+	}
+	return predecessors
+}
+
+// IsOriented reports whether the graph is directed
+func (g *GraphInfo) IsOriented() bool {
+	return g.isOriented
+}
+
+// IsWeighted reports whether the graph is weighted
+func (g *GraphInfo) IsWeighted() bool {
+	return g.isWeighted
+}
+
+// IsMultigraph reports whether the graph allows parallel edges
+func (g *GraphInfo) IsMultigraph() bool {
+	return g.isMultigraph
+}
+
+// IsMixed reports whether the graph has both oriented and non-oriented edges.
+// A mixed graph is also oriented: its non-oriented edges are walked both ways
+func (g *GraphInfo) IsMixed() bool {
+	return g.isMixed
+}
+
+// SetType changes the orientation and weighting flags of the graph without touching its edges.
+// ConvertToDirected, ConvertToUndirected, ConvertToWeighted and ConvertToUnweighted convert the edges as well
+func (g *GraphInfo) SetType(isOriented bool, isWeighted bool) {
+	g.isOriented = isOriented
+	g.isWeighted = isWeighted
+}
+
+// SetMixed allows or forbids mixing oriented and non-oriented edges in the text format.
+// A mixed graph is oriented, so edges without an explicit direction are oriented
+func (g *GraphInfo) SetMixed(isMixed bool) {
+	g.isMixed = isMixed
+	if isMixed {
+		g.isOriented = true
+	}
+}
+
+// NodeDefaults returns the default attributes of the vertices, e.g. of a DOT "node [...]" statement.
+// An attribute of a vertex overrides the default of the same name
+func (g *GraphInfo) NodeDefaults() *Attributes {
+	return &g.nodeDefaults
+}
+
+// EdgeDefaults returns the default attributes of the edges, e.g. of a DOT "edge [...]" statement
+func (g *GraphInfo) EdgeDefaults() *Attributes {
+	return &g.edgeDefaults
+}
+
+// NodeAttributes returns the attributes of the vertex together with the defaults it does not override
+func (g *GraphInfo) NodeAttributes(n *Node) Attributes {
+	return withDefaults(n.Attributes, g.nodeDefaults)
+}
+
+// EdgeAttributes returns the attributes of the edge together with the defaults it does not override
+func (g *GraphInfo) EdgeAttributes(e *Edge) Attributes {
+	return withDefaults(e.Attributes, g.edgeDefaults)
+}
+
+// withDefaults returns attributes with the defaults that are not in them, attributes itself if there are no defaults.
+// An empty attribute over a default unsets it
+func withDefaults(attributes Attributes, defaults Attributes) Attributes {
+	if len(defaults) == 0 {
+		return attributes
+	}
+	result := make(Attributes, len(attributes)+len(defaults))
+	for key, value := range defaults {
+		result[key] = value
+	}
+	for key, value := range attributes {
+		if _, isDefault := defaults[key]; isDefault && value == "" {
+			delete(result, key)
+			continue
+		}
+		result[key] = value
+	}
+	return result
+}
+
+// METHODS
+
+// AddVertex adds n to the graph and assigns it a new id. Vertex values are unique:
+// returns an error if there is already a vertex with the same value
+func AddVertex(g *GraphInfo, n *Node) error {
+	key := valueKey(n.Value)
+	if _, exists := g.nodesByValue[key]; exists {
+		return fmt.Errorf("There is already a vertex '%v'!", n.Value)
+	}
+	n.Id = g.nextNodeId
+	g.nextNodeId++
+	g.nodesById[n.Id] = n
+	g.nodes = append(g.nodes, n)
+	g.connectionsList[n] = nil
+	g.incomingList[n] = nil
+	g.nodesByValue[key] = n
+	return nil
+}
+
+// AddEdge adds an edge from n1 to n2 with a new id. Returns an error if such an edge already exists,
+// unless the graph is a multigraph: then every call adds a new parallel edge
+func AddEdge(g *GraphInfo, n1 *Node, n2 *Node, weight float64) error {
+	_, err := addEdge(g, n1, n2, weight, nil)
+	return err
+}
+
+// addEdge is AddEdge that also sets the typed weight of the edge and returns the edge
+func addEdge(g *GraphInfo, n1 *Node, n2 *Node, weight float64, exact interface{}) (*Edge, error) {
+	if !g.isMultigraph {
+		for _, val := range g.connectionsList[n1] {
+			if !val.undirected && val.List[0] == n1 && val.List[1] == n2 {
+				return nil, fmt.Errorf("There is already an edge from '%v' to '%v' already!", n1.Value, n2.Value)
+			}
+		}
+	}
+	edge := EdgeConstructor(n1, n2, weight)
+	edge.exact = exact
+	insertEdge(g, edge)
+	return edge, nil
+}
+
+// insertEdge gives edge a new id and puts it into the lists of its ends
+func insertEdge(g *GraphInfo, edge *Edge) {
+	edge.Id = g.nextEdgeId
+	g.nextEdgeId++
+	g.edgesById[edge.Id] = edge
+	linkEdge(g, edge)
+}
+
+// linkEdge puts edge into the outgoing and incoming lists of its ends
+func linkEdge(g *GraphInfo, edge *Edge) {
+	n1, n2 := edge.List[0], edge.List[1]
+	g.connectionsList[n1] = append(g.connectionsList[n1], edge)
+	g.incomingList[n2] = append(g.incomingList[n2], edge)
+
+	// A non-oriented edge also goes out of n2 and comes into n1. A loop is listed once
+	if edge.undirected && n1 != n2 {
+		g.connectionsList[n2] = append(g.connectionsList[n2], edge)
+		g.incomingList[n1] = append(g.incomingList[n1], edge)
+	}
+}
+
+func eqByAdress[T any](el1 *T, el2 *T) bool {
+	return el1 == el2
+}
+
+// Removes a_k element by criteria. Returns [a_1, a_2, ..., a_k-1, a_k+1, ..., a_n]
+func removeElementArrayByFunc[T any](el *T, l []*T, f func(*T, *T) bool) []*T {
+	var lst []*T
+	for _, tmp := range l {
+		if f(tmp, el) {
+			continue
+		}
+		lst = append(lst, tmp)
+	}
+	return lst
+}
+
+// Removes a_k element by criteria. Returns [a_1, a_2, ..., a_k-1, a_k+1, ..., a_n]
+func findElementArrayByFunc[T any](el *T, l []*T, f func(*T, *T) bool) int {
+	for i, tmp := range l {
+		if f(tmp, el) {
+			return i
+		}
+	}
+	return -1
+}
+
+// a little workaround. The second element should have node as 'from' (first) element of the list
+func edgeHasNode(el1 *Edge, el2 *Edge) bool {
+	return eqByAdress(el1.List[0], el2.List[0]) || eqByAdress(el1.List[1], el2.List[0])
+}
+
+// RemoveEdge removes e from the outgoing edges of its 'from' vertex and the incoming edges of its 'to' vertex.
+// A non-oriented edge is removed from the lists of both of its ends
+func RemoveEdge(g *GraphInfo, e *Edge) {
+	unlinkEdge(g, e)
+	delete(g.edgesById, e.Id)
+}
+
+// unlinkEdge removes e from the outgoing and incoming lists of its ends, the edge keeps its id
+func unlinkEdge(g *GraphInfo, e *Edge) {
+	for _, n := range e.List {
+		g.connectionsList[n] = removeElementArrayByFunc(e, g.connectionsList[n], eqByAdress)
+		g.incomingList[n] = removeElementArrayByFunc(e, g.incomingList[n], eqByAdress)
+	}
+}
+
+// RemoveVertex removes vertex both from the nodes list and the map: the key and all of the appearances of the vertex in values.
+func RemoveVertex(g *GraphInfo, n *Node) {
+	// Remove from nodes list
+	g.nodes = removeElementArrayByFunc(n, g.nodes, eqByAdress)
+
+	// Remove the node from the value index
+	if key := valueKey(n.Value); g.nodesByValue[key] == n {
+		delete(g.nodesByValue, key)
+	}
+
+	delete(g.nodesById, n.Id)
+	if g.flowSource == n {
+		g.flowSource = nil
+	}
+	if g.flowSink == n {
+		g.flowSink = nil
+	}
+
+	// Remove all edges that point TO this vertex from other nodes' connection lists
+	for _, edge := range g.incomingList[n] {
+		delete(g.edgesById, edge.Id)
+		if from := edge.Other(n); from != n {
+			g.connectionsList[from] = removeElementArrayByFunc(edge, g.connectionsList[from], eqByAdress)
+			g.incomingList[from] = removeElementArrayByFunc(edge, g.incomingList[from], eqByAdress)
+		}
+	}
+
+	// Remove all edges that go FROM this vertex from other nodes' incoming lists
+	for _, edge := range g.connectionsList[n] {
+		delete(g.edgesById, edge.Id)
+		if to := edge.Other(n); to != n {
+			g.connectionsList[to] = removeElementArrayByFunc(edge, g.connectionsList[to], eqByAdress)
+			g.incomingList[to] = removeElementArrayByFunc(edge, g.incomingList[to], eqByAdress)
+		}
+	}
+
+	// Remove the node itself from connectionsList and incomingList (as a key)
+	delete(g.connectionsList, n)
+	delete(g.incomingList, n)
+}
+
+// AddNonWeightedEdge adds an edge from n1 to n2 with zero weight
+func AddNonWeightedEdge(g *GraphInfo, n1 *Node, n2 *Node) error {
+	return AddEdge(g, n1, n2, 0)
+}
+
+// AddNonOrientedEdge adds a non-oriented edge between n1 and n2. The edge is stored once,
+// so removing or reweighting it affects both directions
+func AddNonOrientedEdge(g *GraphInfo, n1 *Node, n2 *Node, weight float64) error {
+	return addNonOrientedEdge(g, n1, n2, weight, nil)
+}
+
+// addNonOrientedEdge is AddNonOrientedEdge that also sets the typed weight of the edges
+func addNonOrientedEdge(g *GraphInfo, n1 *Node, n2 *Node, weight float64, exact interface{}) error {
+	_, err := addNonOrientedEdgeReturning(g, n1, n2, weight, exact)
+	return err
+}
+
+// addNonOrientedEdgeReturning is addNonOrientedEdge that returns the added edge
+func addNonOrientedEdgeReturning(g *GraphInfo, n1 *Node, n2 *Node, weight float64, exact interface{}) (*Edge, error) {
+	if !g.isMultigraph {
+		for _, val := range g.connectionsList[n1] {
+			if val.undirected && val.Other(n1) == n2 {
+				return nil, fmt.Errorf("There is already an edge between '%v' and '%v' already!", n1.Value, n2.Value)
+			}
+		}
+	}
+	edge := EdgeConstructor(n1, n2, weight)
+	edge.exact = exact
+	edge.undirected = true
+	insertEdge(g, edge)
+	return edge, nil
+}
+
+// addOrientedOrNot adds an oriented or a non-oriented edge from n1 to n2
+func addOrientedOrNot(g *GraphInfo, n1 *Node, n2 *Node, weight float64, exact interface{}, oriented bool) (*Edge, error) {
+	if oriented {
+		return addEdge(g, n1, n2, weight, exact)
+	}
+	return addNonOrientedEdgeReturning(g, n1, n2, weight, exact)
+}
+
+// addImportedEdge adds an edge read from a file of another format (DOT, GraphML, ...).
+// These formats allow parallel edges, so the graph becomes a multigraph when the edge already exists
+func addImportedEdge(g *GraphInfo, n1 *Node, n2 *Node, weight float64, exact interface{}, oriented bool) *Edge {
+	edge, err := addOrientedOrNot(g, n1, n2, weight, exact, oriented)
+	if err != nil {
+		g.isMultigraph = true
+		edge, _ = addOrientedOrNot(g, n1, n2, weight, exact, oriented)
+	}
+	return edge
+}
+
+// AddNonOrientedNonWeightedEdge adds a non-oriented edge between n1 and n2 with zero weight
+func AddNonOrientedNonWeightedEdge(g *GraphInfo, n1 *Node, n2 *Node) error {
+	return addNonOrientedEdge(g, n1, n2, 0, nil)
+}
+
+// TODO: change everything below it
+
+// GraphFromFileConstructor reads a graph from the text file at path in the lenient mode
+// and returns it with the skipped lines (see ReadGraphFileLenient).
+// Use ReadGraphFile to fail on the first line that can not be read
+func GraphFromFileConstructor(path string) (*GraphInfo, []*ParseError, error) {
+	return ReadGraphFileLenient(path)
+}
+
+// AddEdgeBetweenNodes adds an edge between the vertices with the given values,
+// creating the vertices if they do not exist yet
+func AddEdgeBetweenNodes(graph *GraphInfo, fromStr, toStr interface{}, weight float64) {
+	addEdgeBetweenNodes(graph, fromStr, toStr, weight, nil)
+}
+
+// addEdgeBetweenNodes is AddEdgeBetweenNodes that also sets the typed weight of the edge.
+// Returns the added edge or an error if it was not added
+func addEdgeBetweenNodes(graph *GraphInfo, fromStr, toStr interface{}, weight float64, exact interface{}) (*Edge, error) {
+	fromNode := FindNodeByValue(graph, fromStr)
+	toNode := FindNodeByValue(graph, toStr)
+
+	if fromNode == nil {
+		fromNode = NodeConstructor(fromStr)
+		AddVertex(graph, fromNode)
+	}
+	if toNode == nil {
+		toNode = NodeConstructor(toStr)
+		AddVertex(graph, toNode)
+	}
+
+	if !graph.isWeighted {
+		weight, exact = 0, nil
+	}
+	return addEdge(graph, fromNode, toNode, weight, exact)
+}
+
+// AddNonOrientedEdgeBetweenNodes adds a non-oriented edge between the vertices with the given values,
+// creating the vertices if they do not exist yet
+func AddNonOrientedEdgeBetweenNodes(graph *GraphInfo, node1Str, node2Str interface{}, weight float64) {
+	addNonOrientedEdgeBetweenNodes(graph, node1Str, node2Str, weight, nil)
+}
+
+// addNonOrientedEdgeBetweenNodes is AddNonOrientedEdgeBetweenNodes that also sets the typed weight of the edges.
+// Returns the added edge or an error if it was not added
+func addNonOrientedEdgeBetweenNodes(graph *GraphInfo, node1Str, node2Str interface{}, weight float64, exact interface{}) (*Edge, error) {
+	node1 := FindNodeByValue(graph, node1Str)
+	node2 := FindNodeByValue(graph, node2Str)
+
+	if node1 == nil {
+		node1 = NodeConstructor(node1Str)
+		AddVertex(graph, node1)
+	}
+	if node2 == nil {
+		node2 = NodeConstructor(node2Str)
+		AddVertex(graph, node2)
+	}
+
+	if !graph.isWeighted {
+		weight, exact = 0, nil
+	}
+	return addNonOrientedEdgeReturning(graph, node1, node2, weight, exact)
+}
+
+// FindNodeByValue returns the vertex holding value or nil if there is none
+func FindNodeByValue(graph *GraphInfo, value interface{}) *Node {
+	return graph.nodesByValue[valueKey(value)]
+}
+
+// valueKey is the key of a vertex value in GraphInfo.nodesByValue.
+// Values are compared with ==, so the integer 1 and the string "1" are different vertices.
+// Values of non-comparable types are compared by their text representation
+func valueKey(value interface{}) interface{} {
+	if value == nil || reflect.TypeOf(value).Comparable() {
+		return value
+	}
+	return fmt.Sprintf("%v", value)
+}
+
+// ParseValue converts the text of a vertex value into the type of the graph's vertex values
+func (g *GraphInfo) ParseValue(valueStr string) (interface{}, error) {
+	if g.parseValue == nil {
+		return valueStr, nil
+	}
+	return g.parseValue(valueStr)
+}
+
+// ParseWeight converts the text of an edge weight into float64 and into the type of the graph's weights.
+// The typed weight is nil for untyped graphs. The weight must be finite: NaN and Inf are rejected by every format
+func (g *GraphInfo) ParseWeight(weightStr string) (float64, interface{}, error) {
+	var weight float64
+	var exact interface{}
+	var err error
+	if g.parseWeight == nil {
+		if weight, err = strconv.ParseFloat(weightStr, 64); err != nil {
+			return 0, nil, fmt.Errorf("invalid weight '%s'", weightStr)
+		}
+	} else if weight, exact, err = g.parseWeight(weightStr); err != nil {
+		return 0, nil, err
+	}
+	if math.IsNaN(weight) || math.IsInf(weight, 0) {
+		return 0, nil, fmt.Errorf("invalid weight '%s': the weight must be a finite number", weightStr)
+	}
+	return weight, exact, nil
+}
+
+// WriteToFile saves the graph to a file in the text format
+func WriteToFile(graph *GraphInfo, path string) error {
+	return WriteFile(graph, path, "text")
+}
+
+// encodeText writes graph in the text format read by ReadGraphFile
+func encodeText(graph *GraphInfo, writer io.Writer) error {
+	// Write graph type
+	graphType := "UNDIRECTED"
+	if graph.isOriented {
+		graphType = "DIRECTED"
+	}
+	if graph.isMixed || hasEdgesAgainstType(graph) {
+		graphType = "MIXED"
+	}
+
+	weightType := "UNWEIGHTED"
+	if graph.isWeighted {
+		weightType = "WEIGHTED"
+	}
+
+	if graph.isMultigraph {
+		weightType += " MULTI"
+	}
+
+	_, err := io.WriteString(writer, fmt.Sprintf("TYPE: %s %s\n", graphType, weightType))
+	if err != nil {
+		return err
+	}
+
+	// Write vertices
+	_, err = io.WriteString(writer, "VERTICES: ")
+	if err != nil {
+		return err
+	}
+
+	vertexStrings := make([]string, 0, len(graph.nodes))
+	for _, node := range graph.nodes {
+		vertexStrings = append(vertexStrings, formatValue(node))
+	}
+
+	_, err = io.WriteString(writer, strings.Join(vertexStrings, ",")+"\n")
+	if err != nil {
+		return err
+	}
+
+	// Write vertex attributes
+	hasAttributes := false
+	// The text format has no default attributes, they are written to every vertex and edge
+	for _, node := range graph.nodes {
+		attributes := graph.NodeAttributes(node)
+		if len(attributes) == 0 {
+			continue
+		}
+		if !hasAttributes {
+			hasAttributes = true
+			if _, err = io.WriteString(writer, "ATTRIBUTES:\n"); err != nil {
+				return err
+			}
+		}
+		_, err = io.WriteString(writer, fmt.Sprintf("%s %s\n", formatValue(node), attributes))
+		if err != nil {
+			return err
+		}
+	}
+
+	// Write edges section header
+	_, err = io.WriteString(writer, "EDGES:\n")
+	if err != nil {
+		return err
+	}
+
+	// Write edges
+	edges := GetAllEdges(graph)
+
+	for _, edge := range edges {
+		fromValue := formatValue(edge.List[0])
+		toValue := formatValue(edge.List[1])
+
+		var edgeLine string
+		if !edge.undirected {
+			if graph.isWeighted {
+				edgeLine = fmt.Sprintf("%s->%s: %s\n", fromValue, toValue, formatWeight(edge))
+			} else {
+				edgeLine = fmt.Sprintf("%s->%s\n", fromValue, toValue)
+			}
+		} else {
+			if graph.isWeighted {
+				edgeLine = fmt.Sprintf("%s-%s: %s\n", fromValue, toValue, formatWeight(edge))
+			} else {
+				edgeLine = fmt.Sprintf("%s-%s\n", fromValue, toValue)
+			}
+		}
+
+		if attributes := graph.EdgeAttributes(edge); len(attributes) > 0 {
+			edgeLine = strings.TrimSuffix(edgeLine, "\n") + " " + attributes.String() + "\n"
+		}
+
+		_, err = io.WriteString(writer, edgeLine)
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// readFileWith reads a new graph from the file at path with decode, which gets the name of the file for its errors
+func readFileWith(path string, decode func(graph *GraphInfo, r io.Reader, name string) error) (*GraphInfo, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return nil, fmt.Errorf("Error opening file: %v", err)
+	}
+	defer file.Close()
+
+	graph := GraphEmptyConstructor()
+	if err := decode(graph, bufio.NewReader(file), path); err != nil {
+		return nil, err
+	}
+	return graph, nil
+}
+
+// WriteFileWith creates the file at path and writes it with encode,
+// e.g. for encoders that take more than the graph, like EncodeSVG with a diagram
+func WriteFileWith(path string, encode func(w io.Writer) error) error {
+	file, err := os.Create(path)
+	if err != nil {
+		return fmt.Errorf("error creating file: %v", err)
+	}
+
+	writer := bufio.NewWriter(file)
+	err = encode(writer)
+	if err == nil {
+		err = writer.Flush()
+	}
+	if closeErr := file.Close(); err == nil {
+		err = closeErr
+	}
+	return err
+}
+
+// hasEdgesAgainstType reports whether an edge's direction differs from the graph's type,
+// e.g. a non-oriented edge added to a directed graph with AddNonOrientedEdge
+func hasEdgesAgainstType(graph *GraphInfo) bool {
+	for _, edge := range graph.edgesById {
+		if edge.IsOriented() != graph.isOriented {
+			return true
+		}
+	}
+	return false
+}
+
+// formatValue returns the value of the vertex as an identifier of the text format,
+// quoted if it contains spaces, dashes, commas etc.
+func formatValue(n *Node) string {
+	return quoteIdentifier(fmt.Sprintf("%v", n.Value))
+}
+
+// formatWeight returns the text of the edge's weight: the typed weight of a typed graph as it is
+// ("1/3" for Rational), the float64 weight in the shortest form that is read back as the same number otherwise
+func formatWeight(edge *Edge) string {
+	switch exact := edge.exact.(type) {
+	case nil:
+		return strconv.FormatFloat(edge.Weight, 'g', -1, 64)
+	case float64:
+		return strconv.FormatFloat(exact, 'g', -1, 64)
+	}
+	return fmt.Sprintf("%v", edge.exact)
+}
@@ -0,0 +1,104 @@
+package graph
+
+import (
+	"bytes"
+	"strconv"
+	"strings"
+	"testing"
+)
+
+// values returns the values of the vertices in their order
+func values(nodes []*Node) []string {
+	result := make([]string, 0, len(nodes))
+	for _, node := range nodes {
+		result = append(result, node.Value.(string))
+	}
+	return result
+}
+
+func TestPredecessors(t *testing.T) {
+	tests := []struct {
+		name  string
+		build func(g *GraphInfo)
+		mixed bool
+		want  map[string][]string
+	}{
+		{"directed", func(g *GraphInfo) {
+			AddEdgeBetweenNodes(g, "A", "B", 0)
+			AddEdgeBetweenNodes(g, "C", "B", 0)
+		}, false, map[string][]string{"A": {}, "B": {"A", "C"}, "C": {}}},
+		{"undirected", func(g *GraphInfo) {
+			AddNonOrientedEdgeBetweenNodes(g, "A", "B", 0)
+			AddNonOrientedEdgeBetweenNodes(g, "B", "C", 0)
+		}, false, map[string][]string{"A": {"B"}, "B": {"A", "C"}, "C": {"B"}}},
+		{"mixed", func(g *GraphInfo) {
+			AddEdgeBetweenNodes(g, "A", "B", 0)
+			AddNonOrientedEdgeBetweenNodes(g, "B", "C", 0)
+			AddEdgeBetweenNodes(g, "C", "A", 0)
+		}, true, map[string][]string{"A": {"C"}, "B": {"A", "C"}, "C": {"B"}}},
+		{"loops", func(g *GraphInfo) {
+			AddVertex(g, NodeConstructor("A"))
+			AddVertex(g, NodeConstructor("B"))
+			AddNonOrientedEdgeBetweenNodes(g, "A", "A", 0)
+			AddEdgeBetweenNodes(g, "B", "B", 0)
+		}, true, map[string][]string{"A": {"A"}, "B": {"B"}}},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			g := GraphConstructor(test.mixed, false)
+			g.SetMixed(test.mixed)
+			test.build(g)
+			for value, want := range test.want {
+				got := values(g.Predecessors(FindNodeByValue(g, value)))
+				if len(got) != len(want) {
+					t.Errorf("predecessors of %s are %v, want %v", value, got, want)
+					continue
+				}
+				for i := range want {
+					if got[i] != want[i] {
+						t.Errorf("predecessors of %s are %v, want %v", value, got, want)
+						break
+					}
+				}
+			}
+		})
+	}
+}
+
+func TestNonFiniteWeights(t *testing.T) {
+	g := GraphConstructor(true, true)
+	AddEdgeBetweenNodes(g, "A", "B", 2.5)
+
+	for _, format := range []string{"text", "dot", "graphml", "gexf", "json", "dimacs", "adjacency", "incidence"} {
+		var buf bytes.Buffer
+		if err := Encode(g, &buf, format); err != nil {
+			t.Fatalf("%s: encode: %v", format, err)
+		}
+		if _, err := Decode(strings.NewReader(buf.String()), format); err != nil {
+			t.Fatalf("%s: decode of\n%s: %v", format, buf.String(), err)
+		}
+
+		for _, weight := range []string{"NaN", "Inf", "+Inf", "-Inf"} {
+			text := buf.String()
+			switch format {
+			case "json":
+				text = strings.ReplaceAll(text, "2.5", strconv.Quote(weight))
+			case "dot":
+				// A label that is not a number is a label, the weight attribute must be a number
+				text = strings.ReplaceAll(text, `label="2.5"`, `weight="`+weight+`"`)
+			case "adjacency":
+				// INF is a missing edge in a matrix
+				if weight == "Inf" || weight == "+Inf" {
+					continue
+				}
+				fallthrough
+			default:
+				text = strings.ReplaceAll(text, "2.5", weight)
+			}
+			if _, err := Decode(strings.NewReader(text), format); err == nil {
+				t.Errorf("%s: the weight %s was read from\n%s", format, weight, text)
+			}
+		}
+	}
+}
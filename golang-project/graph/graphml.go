@@ -0,0 +1,332 @@
+package graph
+
+import (
+	"encoding/xml"
+	"fmt"
+	"io"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// Reading and writing graphs in GraphML (http://graphml.graphdrawing.org), the format of yEd and NetworkX.
+// The data of nodes and edges is read into their attributes, whatever its declared type is.
+// The edge data named "weight" is the weight of the edge. Data with XML content (e.g. yEd graphics)
+// is kept as the XML text. Only the first graph of a file is read, nested graphs, hyperedges and ports are not supported
+
+const graphMLNamespace = "http://graphml.graphdrawing.org/xmlns"
+
+type graphMLDocument struct {
+	XMLName xml.Name       `xml:"graphml"`
+	Xmlns   string         `xml:"xmlns,attr,omitempty"`
+	Keys    []graphMLKey   `xml:"key"`
+	Graphs  []graphMLGraph `xml:"graph"`
+}
+
+type graphMLKey struct {
+	ID      string  `xml:"id,attr"`
+	For     string  `xml:"for,attr,omitempty"`
+	Name    string  `xml:"attr.name,attr,omitempty"`
+	Type    string  `xml:"attr.type,attr,omitempty"`
+	Default *string `xml:"default,omitempty"`
+}
+
+type graphMLGraph struct {
+	ID          string        `xml:"id,attr,omitempty"`
+	EdgeDefault string        `xml:"edgedefault,attr,omitempty"`
+	Nodes       []graphMLNode `xml:"node"`
+	Edges       []graphMLEdge `xml:"edge"`
+}
+
+type graphMLNode struct {
+	ID    string         `xml:"id,attr"`
+	Data  []graphMLData  `xml:"data"`
+	Graph []graphMLGraph `xml:"graph"`
+}
+
+type graphMLEdge struct {
+	ID       string        `xml:"id,attr,omitempty"`
+	Source   string        `xml:"source,attr"`
+	Target   string        `xml:"target,attr"`
+	Directed string        `xml:"directed,attr,omitempty"`
+	Data     []graphMLData `xml:"data"`
+}
+
+type graphMLData struct {
+	Key   string `xml:"key,attr"`
+	Text  string `xml:",chardata"`
+	Inner string `xml:",innerxml"` // only read: the raw content, if it is XML it is kept as it is
+}
+
+// value returns the text of the data, or its raw XML content if it has elements
+func (d graphMLData) value() string {
+	if strings.Contains(d.Inner, "<") {
+		return strings.TrimSpace(d.Inner)
+	}
+	return d.Text
+}
+
+// ReadGraphML reads a graph from the GraphML file at path
+func ReadGraphML(path string) (*GraphInfo, error) {
+	return ReadFile(path, "graphml")
+}
+
+// WriteGraphML saves the graph to a GraphML file. Attributes become data keys
+// typed by their values (long, double, boolean or string), the weight is the edge data "weight"
+func WriteGraphML(graph *GraphInfo, path string) error {
+	return WriteFile(graph, path, "graphml")
+}
+
+// decodeXML reads an XML document into v. Syntax errors are returned as *ParseError
+func decodeXML(r io.Reader, name string, v interface{}) error {
+	if err := xml.NewDecoder(r).Decode(v); err != nil {
+		if syntaxErr, ok := err.(*xml.SyntaxError); ok {
+			return &ParseError{Path: name, Line: syntaxErr.Line, Column: 1, Msg: syntaxErr.Msg}
+		}
+		return fmt.Errorf("%s: %v", name, err)
+	}
+	return nil
+}
+
+// decodeGraphML reads a GraphML document into graph. name is the name of the file for the errors
+func decodeGraphML(graph *GraphInfo, r io.Reader, name string) error {
+	var doc graphMLDocument
+	if err := decodeXML(r, name, &doc); err != nil {
+		return err
+	}
+	if len(doc.Graphs) != 1 {
+		return fmt.Errorf("%s: expected one graph, found %d", name, len(doc.Graphs))
+	}
+	g := doc.Graphs[0]
+
+	keys := make(map[string]graphMLKey)
+	for _, key := range doc.Keys {
+		keys[key.ID] = key
+	}
+	// attributesOf returns the attributes of a node or an edge: the defaults of the keys and then the data
+	attributesOf := func(kind string, data []graphMLData) Attributes {
+		var attributes Attributes
+		for _, key := range doc.Keys {
+			if key.Default != nil && (key.For == kind || key.For == "all") {
+				attributes.Set(graphMLKeyName(key), *key.Default)
+			}
+		}
+		for _, d := range data {
+			key, ok := keys[d.Key]
+			if !ok {
+				key = graphMLKey{ID: d.Key}
+			}
+			attributes.Set(graphMLKeyName(key), d.value())
+		}
+		return attributes
+	}
+
+	graph.isOriented = g.EdgeDefault != "undirected"
+
+	for _, n := range g.Nodes {
+		if len(n.Graph) > 0 {
+			return fmt.Errorf("%s: node '%s': nested graphs are not supported", name, n.ID)
+		}
+		value, err := graph.ParseValue(n.ID)
+		if err != nil {
+			return fmt.Errorf("%s: node '%s': %v", name, n.ID, err)
+		}
+		node := NodeConstructor(value)
+		node.Attributes = attributesOf("node", n.Data)
+		if err := AddVertex(graph, node); err != nil {
+			return fmt.Errorf("%s: duplicate node '%s'", name, n.ID)
+		}
+	}
+
+	for _, e := range g.Edges {
+		ends := [2]*Node{}
+		for i, id := range []string{e.Source, e.Target} {
+			value, err := graph.ParseValue(id)
+			if err != nil {
+				return fmt.Errorf("%s: edge '%s': %v", name, e.ID, err)
+			}
+			if ends[i] = FindNodeByValue(graph, value); ends[i] == nil {
+				return fmt.Errorf("%s: edge '%s': unknown node '%s'", name, e.ID, id)
+			}
+		}
+
+		attributes := attributesOf("edge", e.Data)
+		var weight float64
+		var exact interface{}
+		if weightStr, ok := attributes.Get("weight"); ok {
+			var err error
+			if weight, exact, err = graph.ParseWeight(strings.TrimSpace(weightStr)); err != nil {
+				return fmt.Errorf("%s: edge '%s': %v", name, e.ID, err)
+			}
+			attributes.Delete("weight")
+			graph.isWeighted = true
+		}
+
+		oriented := graph.isOriented
+		if e.Directed != "" {
+			oriented = e.Directed == "true"
+			if oriented != graph.isOriented {
+				graph.isMixed = true
+			}
+		}
+
+		edge := addImportedEdge(graph, ends[0], ends[1], weight, exact, oriented)
+		if len(attributes) > 0 {
+			edge.Attributes = attributes
+		}
+	}
+
+	// A mixed graph is oriented, its non-oriented edges are walked both ways
+	if graph.isMixed {
+		graph.isOriented = true
+	}
+	return nil
+}
+
+// graphMLKeyName is the name of the attribute of a data key: its attr.name or its id if it has no name
+func graphMLKeyName(key graphMLKey) string {
+	if key.Name != "" {
+		return key.Name
+	}
+	return key.ID
+}
+
+// encodeGraphML writes graph as a GraphML document
+func encodeGraphML(graph *GraphInfo, w io.Writer) error {
+	doc := graphMLDocument{Xmlns: graphMLNamespace}
+	edgeDefault := "undirected"
+	if graph.isOriented {
+		edgeDefault = "directed"
+	}
+	g := graphMLGraph{ID: "G", EdgeDefault: edgeDefault}
+
+	edges := GetAllEdges(graph)
+	nodeKeys := declareGraphMLKeys(&doc, "node", "n", nodeAttributeValues(graph))
+	edgeKeys := declareGraphMLKeys(&doc, "edge", "e", edgeAttributeValues(graph, edges))
+	if graph.isWeighted {
+		doc.Keys = append(doc.Keys, graphMLKey{ID: "weight", For: "edge", Name: "weight", Type: weightType(edges)})
+	}
+
+	for _, node := range graph.nodes {
+		n := graphMLNode{ID: fmt.Sprintf("%v", node.Value)}
+		attributes := graph.NodeAttributes(node)
+		for _, key := range attributes.Keys() {
+			n.Data = append(n.Data, graphMLData{Key: nodeKeys[key], Text: attributes[key]})
+		}
+		g.Nodes = append(g.Nodes, n)
+	}
+
+	for _, edge := range edges {
+		e := graphMLEdge{
+			ID:     fmt.Sprintf("e%d", edge.Id),
+			Source: fmt.Sprintf("%v", edge.List[0].Value),
+			Target: fmt.Sprintf("%v", edge.List[1].Value),
+		}
+		if edge.IsOriented() != graph.isOriented {
+			e.Directed = strconv.FormatBool(edge.IsOriented())
+		}
+		attributes := graph.EdgeAttributes(edge)
+		for _, key := range attributes.Keys() {
+			e.Data = append(e.Data, graphMLData{Key: edgeKeys[key], Text: attributes[key]})
+		}
+		if graph.isWeighted {
+			e.Data = append(e.Data, graphMLData{Key: "weight", Text: formatWeight(edge)})
+		}
+		g.Edges = append(g.Edges, e)
+	}
+	doc.Graphs = []graphMLGraph{g}
+
+	return encodeXML(w, doc)
+}
+
+// encodeXML writes v as an indented XML document
+func encodeXML(w io.Writer, v interface{}) error {
+	if _, err := io.WriteString(w, xml.Header); err != nil {
+		return err
+	}
+	encoder := xml.NewEncoder(w)
+	encoder.Indent("", "  ")
+	if err := encoder.Encode(v); err != nil {
+		return err
+	}
+	_, err := io.WriteString(w, "\n")
+	return err
+}
+
+// declareGraphMLKeys adds a data key for every attribute name and returns the ids of the keys by name
+func declareGraphMLKeys(doc *graphMLDocument, kind string, prefix string, values map[string][]string) map[string]string {
+	ids := make(map[string]string)
+	for i, name := range sortedKeys(values) {
+		ids[name] = fmt.Sprintf("%s%d", prefix, i)
+		doc.Keys = append(doc.Keys, graphMLKey{ID: ids[name], For: kind, Name: name, Type: attributeType(values[name])})
+	}
+	return ids
+}
+
+// nodeAttributeValues returns the values of every attribute of the vertices of the graph by its name
+func nodeAttributeValues(graph *GraphInfo) map[string][]string {
+	values := make(map[string][]string)
+	for _, node := range graph.nodes {
+		for key, value := range graph.NodeAttributes(node) {
+			values[key] = append(values[key], value)
+		}
+	}
+	return values
+}
+
+// edgeAttributeValues returns the values of every attribute of the edges of the graph by its name
+func edgeAttributeValues(graph *GraphInfo, edges []*Edge) map[string][]string {
+	values := make(map[string][]string)
+	for _, edge := range edges {
+		for key, value := range graph.EdgeAttributes(edge) {
+			values[key] = append(values[key], value)
+		}
+	}
+	return values
+}
+
+func sortedKeys[V any](values map[string]V) []string {
+	keys := make([]string, 0, len(values))
+	for key := range values {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+// attributeType returns the type of a GraphML / GEXF attribute that fits all of its values:
+// boolean, long, double or string
+func attributeType(values []string) string {
+	isType := func(parse func(string) error) bool {
+		for _, value := range values {
+			if parse(value) != nil {
+				return false
+			}
+		}
+		return true
+	}
+
+	switch {
+	case isType(func(s string) error { _, err := strconv.ParseBool(s); return err }):
+		return "boolean"
+	case isType(func(s string) error { _, err := strconv.ParseInt(s, 10, 64); return err }):
+		return "long"
+	case isType(func(s string) error { _, err := strconv.ParseFloat(s, 64); return err }):
+		return "double"
+	}
+	return "string"
+}
+
+// weightType returns the type of the weight attribute: long for integer typed weights,
+// string for Rational ones (they are written as "num/den"), double otherwise
+func weightType(edges []*Edge) string {
+	for _, edge := range edges {
+		switch edge.exact.(type) {
+		case int, int64:
+			return "long"
+		case Rational:
+			return "string"
+		}
+	}
+	return "double"
+}
@@ -0,0 +1,125 @@
+package graph
+
+import (
+	"strings"
+	"testing"
+)
+
+// xmlRoundTripGraphs returns the graphs that are written to an XML format and read back
+func xmlRoundTripGraphs() []struct {
+	name string
+	g    *GraphInfo
+} {
+	weighted := MultigraphConstructor(true, true)
+	AddEdgeBetweenNodes(weighted, "A", "B", 2.5)
+	AddEdgeBetweenNodes(weighted, "A", "B", -1)
+	AddEdgeBetweenNodes(weighted, "New York", "A", 0)
+	weighted.Nodes()[0].Attributes.Set("population", "120")
+	weighted.Nodes()[1].Attributes.Set("population", "7.5")
+	GetAllEdges(weighted)[0].Attributes.Set("toll", "true")
+	GetAllEdges(weighted)[1].Attributes.Set("road", "a <quoted> & \"odd\" value")
+
+	unweighted := GraphConstructor(false, false)
+	AddNonOrientedEdgeBetweenNodes(unweighted, "A", "B", 0)
+	AddNonOrientedEdgeBetweenNodes(unweighted, "B", "C", 0)
+	AddVertex(unweighted, NodeConstructor("D"))
+
+	mixed := GraphConstructor(true, true)
+	mixed.SetMixed(true)
+	AddEdgeBetweenNodes(mixed, "A", "B", 1)
+	AddNonOrientedEdgeBetweenNodes(mixed, "B", "C", 2)
+
+	return []struct {
+		name string
+		g    *GraphInfo
+	}{
+		{"weighted multigraph with attributes", weighted},
+		{"unweighted with an isolated vertex", unweighted},
+		{"mixed", mixed},
+	}
+}
+
+// assertXMLRoundTrip writes every graph of xmlRoundTripGraphs in the format and checks that it is read back the same
+func assertXMLRoundTrip(t *testing.T, format string) {
+	for _, test := range xmlRoundTripGraphs() {
+		t.Run(test.name, func(t *testing.T) {
+			encoded := encodeString(t, test.g, format)
+			back := decodeString(t, encoded, format)
+			assertSameGraph(t, test.g, back)
+			if got, want := encodeString(t, back, "text"), encodeString(t, test.g, "text"); got != want {
+				t.Errorf("the graph changed after a round trip through\n%s\ngot\n%s\nwant\n%s", encoded, got, want)
+			}
+		})
+	}
+}
+
+// assertDecodeErrors checks that every document fails to decode in the format with an error containing want
+func assertDecodeErrors(t *testing.T, format string, tests []struct{ name, src, want string }) {
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			_, err := Decode(strings.NewReader(test.src), format)
+			if err == nil {
+				t.Fatalf("no error")
+			}
+			if !strings.Contains(err.Error(), test.want) {
+				t.Errorf("error %q, want %q", err, test.want)
+			}
+		})
+	}
+}
+
+func TestGraphMLRoundTrip(t *testing.T) {
+	assertXMLRoundTrip(t, "graphml")
+}
+
+func TestGraphMLDecode(t *testing.T) {
+	g := decodeString(t, `<?xml version="1.0" encoding="UTF-8"?>
+<graphml xmlns="http://graphml.graphdrawing.org/xmlns">
+  <key id="d0" for="node" attr.name="color" attr.type="string"><default>gray</default></key>
+  <key id="d1" for="edge" attr.name="weight" attr.type="double"/>
+  <key id="d2" for="node" attr.name="graphics"/>
+  <graph id="G" edgedefault="undirected">
+    <node id="a"><data key="d0">red</data></node>
+    <node id="b"><data key="d2"><y:ShapeNode><y:Fill color="#FFCC00"/></y:ShapeNode></data></node>
+    <node id="c"/>
+    <edge source="a" target="b"><data key="d1">1.5</data></edge>
+    <edge source="b" target="c" directed="true"><data key="d1">2</data><data key="note">one way</data></edge>
+  </graph>
+</graphml>`, "graphml")
+
+	if !g.IsOriented() || !g.IsMixed() || !g.IsWeighted() {
+		t.Errorf("oriented %v, mixed %v, weighted %v, want a weighted mixed graph", g.IsOriented(), g.IsMixed(), g.IsWeighted())
+	}
+	if color, _ := FindNodeByValue(g, "a").Attributes.Get("color"); color != "red" {
+		t.Errorf("the color of a is %q, want red", color)
+	}
+	if color, _ := FindNodeByValue(g, "c").Attributes.Get("color"); color != "gray" {
+		t.Errorf("the color of c is %q, want the default gray", color)
+	}
+	if graphics, _ := FindNodeByValue(g, "b").Attributes.Get("graphics"); !strings.HasPrefix(graphics, "<y:ShapeNode>") {
+		t.Errorf("the XML data of b is %q, want it as it is", graphics)
+	}
+	edges := GetAllEdges(g)
+	if len(edges) != 2 || edges[0].Weight != 1.5 || edges[0].IsOriented() || !edges[1].IsOriented() {
+		t.Fatalf("edges %v", edges)
+	}
+	if _, ok := edges[0].Attributes.Get("weight"); ok {
+		t.Errorf("the weight is kept as an attribute too")
+	}
+	if note, _ := edges[1].Attributes.Get("note"); note != "one way" {
+		t.Errorf("the data of an undeclared key is %q, want one way", note)
+	}
+}
+
+func TestGraphMLDecodeErrors(t *testing.T) {
+	assertDecodeErrors(t, "graphml", []struct{ name, src, want string }{
+		{"syntax error", "<graphml>\n<graph>\n<node id=\"a\">\n</graph>\n</graphml>", "<input>:4:"},
+		{"no graph", `<graphml></graphml>`, "expected one graph, found 0"},
+		{"duplicate node", `<graphml><graph><node id="a"/><node id="a"/></graph></graphml>`, "duplicate node 'a'"},
+		{"unknown node", `<graphml><graph><node id="a"/><edge id="e0" source="a" target="b"/></graph></graphml>`,
+			"edge 'e0': unknown node 'b'"},
+		{"invalid weight", `<graphml><key id="w" for="edge" attr.name="weight"/><graph><node id="a"/>` +
+			`<edge id="e0" source="a" target="a"><data key="w">heavy</data></edge></graph></graphml>`, "invalid weight 'heavy'"},
+		{"nested graph", `<graphml><graph><node id="a"><graph/></node></graph></graphml>`, "nested graphs are not supported"},
+	})
+}
@@ -0,0 +1,91 @@
+package graph
+
+// Highlight is a set of edges to emphasize when a graph is exported, e.g. the MST found by Prim
+// or the min cut found by EdmondsKarp. Edges are identified by themselves, not by their ends,
+// so of the parallel edges of a multigraph only those in the set are highlighted. A nil Highlight is empty
+type Highlight struct {
+	edges map[*Edge]bool
+}
+
+// NewHighlight returns an empty set of highlighted edges
+func NewHighlight() *Highlight {
+	return &Highlight{edges: make(map[*Edge]bool)}
+}
+
+// Add highlights the edge e
+func (h *Highlight) Add(e *Edge) {
+	h.edges[e] = true
+}
+
+// Contains reports whether e is highlighted
+func (h *Highlight) Contains(e *Edge) bool {
+	if h == nil {
+		return false
+	}
+	return h.edges[e]
+}
+
+// HighlightMST highlights the edges of the minimum spanning tree found by Prim
+func HighlightMST(result *PrimResult) *Highlight {
+	h := NewHighlight()
+	for _, edge := range result.MSTEdges {
+		h.Add(edge)
+	}
+	return h
+}
+
+// HighlightMinCut highlights the edges of the minimal cut found by EdmondsKarp,
+// i.e. all the edges of the graph merged into the cut edges of the flow network
+func HighlightMinCut(result *MaxFlowResult) *Highlight {
+	h := NewHighlight()
+	for _, flowEdge := range result.MinCut {
+		for _, edge := range flowEdge.Edges {
+			h.Add(edge)
+		}
+	}
+	return h
+}
+
+// HighlightPath highlights the edges of the shortest path found by BellmanFord to target,
+// the one returned by BellmanFordResult.ReconstructPath
+func HighlightPath(result *BellmanFordResult, target *Node) *Highlight {
+	h := NewHighlight()
+	path := result.ReconstructPath(target)
+	for i := len(path) - 1; i > 0; i-- {
+		if edge := result.PredecessorEdges[path[i]]; edge != nil {
+			h.Add(edge)
+		}
+	}
+	return h
+}
+
+// HighlightShortestPaths highlights the tree of the shortest paths found by BellmanFord:
+// the last edge of the path to every vertex that has one. The vertices reachable from
+// a negative cycle have no shortest path
+func HighlightShortestPaths(result *BellmanFordResult) *Highlight {
+	h := NewHighlight()
+	noPath := make(map[*Node]bool)
+	for _, node := range result.NegativeCycleNodes {
+		noPath[node] = true
+	}
+	for node, edge := range result.PredecessorEdges {
+		if edge != nil && !noPath[node] {
+			h.Add(edge)
+		}
+	}
+	return h
+}
+
+// HighlightFlow highlights the edges that carry the flow found by EdmondsKarp.
+// The flow of a flow network edge is drawn on all the edges of the graph merged into it
+func HighlightFlow(result *MaxFlowResult) *Highlight {
+	h := NewHighlight()
+	for flowEdge, flow := range result.Flow {
+		if flow > 0 {
+			for _, edge := range flowEdge.Edges {
+				h.Add(edge)
+			}
+		}
+	}
+	return h
+}
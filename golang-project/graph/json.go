@@ -0,0 +1,521 @@
+package graph
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"math"
+	"sort"
+	"strconv"
+)
+
+// The JSON format of a graph:
+//
+//	{
+//	  "oriented": true,       // the type flags, false if omitted
+//	  "weighted": true,
+//	  "multigraph": false,
+//	  "mixed": false,
+//	  "nodes": [
+//	    {"id": "A", "number": 0, "attributes": {"color": "red"}},
+//	    {"id": "B", "number": 2}
+//	  ],
+//	  "edges": [
+//	    {"id": 1, "from": "A", "to": "B", "oriented": true, "weight": 5, "attributes": {"label": "road"}}
+//	  ],
+//	  "source": "A",          // the source and the sink of a flow network (see FlowTerminals), if set
+//	  "sink": "B",
+//	  "nextNodeId": 3,        // the ids given to the next vertex and edge
+//	  "nextEdgeId": 2
+//	}
+//
+// Vertex ids are the values of the vertices as text, edges refer to them in "from" and "to".
+// "number" is the numeric id of a vertex (Node.Id), "id" of an edge is Edge.Id.
+// "weight" is a number, a Rational weight is a string ("1/3"); it is omitted in unweighted graphs.
+// "oriented" of an edge defaults to the type of the graph. The numeric ids and the counters are kept
+// when the graph is read, so the ids of removed vertices and edges are not given out again;
+// a vertex or an edge without one gets the next free id.
+// A document must have "nodes" or "edges", a workspace file is rejected with ErrWorkspace.
+//
+// The results of the algorithms (see their MarshalJSON and DistancesJSON) use the same vertex ids. A distance is a number,
+// null if the vertex is unreachable and the string "-inf" if it can be made arbitrarily small by a negative cycle
+
+type jsonGraph struct {
+	Oriented   bool       `json:"oriented,omitempty"`
+	Weighted   bool       `json:"weighted,omitempty"`
+	Multigraph bool       `json:"multigraph,omitempty"`
+	Mixed      bool       `json:"mixed,omitempty"`
+	Nodes      []jsonNode `json:"nodes"`
+	Edges      []jsonEdge `json:"edges"`
+	Source     *string    `json:"source,omitempty"`
+	Sink       *string    `json:"sink,omitempty"`
+	NextNodeId uint32     `json:"nextNodeId,omitempty"`
+	NextEdgeId uint32     `json:"nextEdgeId,omitempty"`
+}
+
+type jsonNode struct {
+	ID         string     `json:"id"`
+	Number     *uint32    `json:"number,omitempty"`
+	Attributes Attributes `json:"attributes,omitempty"`
+}
+
+type jsonEdge struct {
+	ID         *uint32         `json:"id,omitempty"`
+	From       string          `json:"from"`
+	To         string          `json:"to"`
+	Oriented   *bool           `json:"oriented,omitempty"`
+	Weight     json.RawMessage `json:"weight,omitempty"`
+	Attributes Attributes      `json:"attributes,omitempty"`
+}
+
+// ReadJSON reads a graph from the JSON file at path
+func ReadJSON(path string) (*GraphInfo, error) {
+	return ReadFile(path, "json")
+}
+
+// WriteJSON saves the graph to a JSON file
+func WriteJSON(graph *GraphInfo, path string) error {
+	return WriteFile(graph, path, "json")
+}
+
+// decodeJSON reads a JSON graph into graph. name is the name of the file for the errors
+func decodeJSON(graph *GraphInfo, r io.Reader, name string) error {
+	data, err := io.ReadAll(r)
+	if err != nil {
+		return fmt.Errorf("%s: %v", name, err)
+	}
+	if err := graph.UnmarshalJSON(data); err != nil {
+		if syntaxErr, ok := err.(*json.SyntaxError); ok {
+			line, column := positionOf(data, syntaxErr.Offset)
+			return &ParseError{Path: name, Line: line, Column: column, Msg: syntaxErr.Error()}
+		}
+		return fmt.Errorf("%s: %w", name, err)
+	}
+	return nil
+}
+
+// encodeJSON writes graph as an indented JSON document
+func encodeJSON(graph *GraphInfo, w io.Writer) error {
+	encoder := json.NewEncoder(w)
+	encoder.SetIndent("", "  ")
+	return encoder.Encode(graph)
+}
+
+// positionOf returns the line and the column of the byte at offset in data, starting with 1
+func positionOf(data []byte, offset int64) (int, int) {
+	if offset > int64(len(data)) {
+		offset = int64(len(data))
+	}
+	before := data[:offset]
+	line := bytes.Count(before, []byte("\n")) + 1
+	column := len(before) - bytes.LastIndexByte(before, '\n')
+	return line, column
+}
+
+// MarshalJSON returns the graph in the JSON format described above
+func (g *GraphInfo) MarshalJSON() ([]byte, error) {
+	doc := jsonGraph{
+		Oriented:   g.isOriented,
+		Weighted:   g.isWeighted,
+		Multigraph: g.isMultigraph,
+		Mixed:      g.isMixed || hasEdgesAgainstType(g),
+		Nodes:      make([]jsonNode, 0, len(g.nodes)),
+		Edges:      make([]jsonEdge, 0, len(g.edgesById)),
+		NextNodeId: g.nextNodeId,
+		NextEdgeId: g.nextEdgeId,
+	}
+	for _, node := range g.nodes {
+		number := node.Id
+		doc.Nodes = append(doc.Nodes, jsonNode{ID: jsonNodeId(node), Number: &number, Attributes: g.NodeAttributes(node)})
+	}
+	for _, edge := range GetAllEdges(g) {
+		doc.Edges = append(doc.Edges, g.jsonEdgeOf(edge))
+	}
+	if g.flowSource != nil {
+		id := jsonNodeId(g.flowSource)
+		doc.Source = &id
+	}
+	if g.flowSink != nil {
+		id := jsonNodeId(g.flowSink)
+		doc.Sink = &id
+	}
+	return json.Marshal(doc)
+}
+
+// UnmarshalJSON replaces the vertices and edges of the graph with the ones of a JSON graph.
+// The vertex values and weights of a typed graph are read into its types
+func (g *GraphInfo) UnmarshalJSON(data []byte) error {
+	var keys map[string]json.RawMessage
+	if err := json.Unmarshal(data, &keys); err != nil {
+		return err
+	}
+	for key := range keys {
+		if workspaceKeys[key] {
+			return ErrWorkspace
+		}
+	}
+	if _, ok := keys["nodes"]; !ok {
+		if _, ok := keys["edges"]; !ok {
+			return fmt.Errorf("not a JSON graph: it has neither \"nodes\" nor \"edges\"")
+		}
+	}
+
+	var doc jsonGraph
+	if err := json.Unmarshal(data, &doc); err != nil {
+		return err
+	}
+
+	fresh := GraphEmptyConstructor()
+	fresh.parseValue, fresh.parseWeight = g.parseValue, g.parseWeight
+	fresh.isOriented = doc.Oriented || doc.Mixed
+	fresh.isWeighted = doc.Weighted
+	fresh.isMultigraph = doc.Multigraph
+	fresh.isMixed = doc.Mixed
+
+	for i, n := range doc.Nodes {
+		value, err := fresh.ParseValue(n.ID)
+		if err != nil {
+			return fmt.Errorf("node %d '%s': %v", i+1, n.ID, err)
+		}
+		node := NodeConstructor(value)
+		node.Attributes = n.Attributes
+		if err := AddVertex(fresh, node); err != nil {
+			return fmt.Errorf("node %d: duplicate vertex '%s'", i+1, n.ID)
+		}
+	}
+
+	var err error
+	if fresh.flowSource, err = jsonTerminal(fresh, doc.Source, "source"); err != nil {
+		return err
+	}
+	if fresh.flowSink, err = jsonTerminal(fresh, doc.Sink, "sink"); err != nil {
+		return err
+	}
+
+	edges := make([]*Edge, len(doc.Edges))
+	for i, e := range doc.Edges {
+		ends := [2]*Node{}
+		for j, id := range []string{e.From, e.To} {
+			value, err := fresh.ParseValue(id)
+			if err != nil {
+				return fmt.Errorf("edge %d: %v", i+1, err)
+			}
+			if ends[j] = FindNodeByValue(fresh, value); ends[j] == nil {
+				return fmt.Errorf("edge %d: unknown vertex '%s'", i+1, id)
+			}
+		}
+
+		var weight float64
+		var exact interface{}
+		if fresh.isWeighted && len(e.Weight) > 0 {
+			var err error
+			if weight, exact, err = fresh.ParseWeight(jsonWeightText(e.Weight)); err != nil {
+				return fmt.Errorf("edge %d: %v", i+1, err)
+			}
+		}
+
+		oriented := fresh.isOriented
+		if e.Oriented != nil {
+			oriented = *e.Oriented
+		}
+		if oriented != fresh.isOriented && !fresh.isMixed {
+			return fmt.Errorf("edge %d '%s'-'%s': the direction does not match the type of the graph, it is not mixed", i+1, e.From, e.To)
+		}
+
+		edge, err := addOrientedOrNot(fresh, ends[0], ends[1], weight, exact, oriented)
+		if err != nil {
+			return fmt.Errorf("edge %d: %v", i+1, err)
+		}
+		edge.Attributes = e.Attributes
+		edges[i] = edge
+	}
+
+	// The vertices and the edges keep their ids
+	nodeIds := make([]*uint32, len(doc.Nodes))
+	for i, n := range doc.Nodes {
+		nodeIds[i] = n.Number
+	}
+	fresh.nodesById = make(map[uint32]*Node, len(fresh.nodes))
+	fresh.nextNodeId = doc.NextNodeId
+	err = restoreIds(nodeIds, &fresh.nextNodeId, "node", func(i int, id uint32) {
+		fresh.nodes[i].Id = id
+		fresh.nodesById[id] = fresh.nodes[i]
+	})
+	if err != nil {
+		return err
+	}
+
+	edgeIds := make([]*uint32, len(doc.Edges))
+	for i, e := range doc.Edges {
+		edgeIds[i] = e.ID
+	}
+	fresh.edgesById = make(map[uint32]*Edge, len(edges))
+	fresh.nextEdgeId = doc.NextEdgeId
+	err = restoreIds(edgeIds, &fresh.nextEdgeId, "edge", func(i int, id uint32) {
+		edges[i].Id = id
+		fresh.edgesById[id] = edges[i]
+	})
+	if err != nil {
+		return err
+	}
+
+	*g = *fresh
+	return nil
+}
+
+// restoreIds gives the i-th of the elements read the id ids[i] with setId. The ones without an id (nil)
+// are numbered after the largest id and next, the counter of the ids, which is moved past all of them.
+// what is the name of the elements for the errors
+func restoreIds(ids []*uint32, next *uint32, what string, setId func(i int, id uint32)) error {
+	seen := make(map[uint32]bool, len(ids))
+	for i, id := range ids {
+		if id == nil {
+			continue
+		}
+		if seen[*id] {
+			return fmt.Errorf("%s %d: duplicate %s id %d", what, i+1, what, *id)
+		}
+		seen[*id] = true
+		if *id >= *next {
+			*next = *id + 1
+		}
+	}
+	for i, id := range ids {
+		if id != nil {
+			setId(i, *id)
+			continue
+		}
+		setId(i, *next)
+		*next++
+	}
+	return nil
+}
+
+// MarshalJSON returns the typed graph in the JSON format of GraphInfo
+func (g *Graph[K, W]) MarshalJSON() ([]byte, error) {
+	return g.info.MarshalJSON()
+}
+
+// UnmarshalJSON reads a JSON graph into the typed graph, the vertex values and weights must have its types
+func (g *Graph[K, W]) UnmarshalJSON(data []byte) error {
+	if g.info == nil {
+		g.info = GraphEmptyConstructor()
+		g.setParsers()
+	}
+	return g.info.UnmarshalJSON(data)
+}
+
+// jsonTerminal returns the vertex of the "source" or the "sink" of a JSON graph, nil if it is not set
+func jsonTerminal(g *GraphInfo, id *string, name string) (*Node, error) {
+	if id == nil {
+		return nil, nil
+	}
+	value, err := g.ParseValue(*id)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %v", name, err)
+	}
+	node := FindNodeByValue(g, value)
+	if node == nil {
+		return nil, fmt.Errorf("%s: unknown vertex '%s'", name, *id)
+	}
+	return node, nil
+}
+
+// jsonNodeId returns the id of a vertex in JSON: its value as text
+func jsonNodeId(n *Node) string {
+	return fmt.Sprintf("%v", n.Value)
+}
+
+// jsonEdgeOf returns the JSON form of an edge of g
+func (g *GraphInfo) jsonEdgeOf(edge *Edge) jsonEdge {
+	oriented := edge.IsOriented()
+	id := edge.Id
+	e := jsonEdge{
+		ID:         &id,
+		From:       jsonNodeId(edge.List[0]),
+		To:         jsonNodeId(edge.List[1]),
+		Oriented:   &oriented,
+		Attributes: g.EdgeAttributes(edge),
+	}
+	if g.isWeighted {
+		e.Weight = jsonWeight(edge)
+	}
+	return e
+}
+
+// jsonWeight returns the weight of the edge as a JSON number, or as a string if it is not a valid
+// JSON number (Rational weights, infinities)
+func jsonWeight(edge *Edge) json.RawMessage {
+	text := formatWeight(edge)
+	if json.Valid([]byte(text)) {
+		return json.RawMessage(text)
+	}
+	return json.RawMessage(strconv.Quote(text))
+}
+
+// jsonWeightText returns the text of a weight written as a JSON number or string
+func jsonWeightText(raw json.RawMessage) string {
+	var text string
+	if json.Unmarshal(raw, &text) == nil {
+		return text
+	}
+	return string(raw)
+}
+
+// jsonDistance returns a distance found by an algorithm for JSON: the number,
+// nil for an unreachable vertex (+Inf) and "-inf" for a vertex behind a negative cycle
+func jsonDistance(d float64) interface{} {
+	switch {
+	case math.IsInf(d, 1), math.IsNaN(d):
+		return nil
+	case math.IsInf(d, -1):
+		return "-inf"
+	}
+	return d
+}
+
+// jsonResultEdge is an edge found by an algorithm, e.g. an edge of the MST
+type jsonResultEdge struct {
+	From   string          `json:"from"`
+	To     string          `json:"to"`
+	Weight json.RawMessage `json:"weight"`
+}
+
+// MarshalJSON returns the MST as
+// {"edges": [{"from": "A", "to": "B", "weight": 2}, ...], "totalWeight": 7, "isConnected": true}
+func (r *PrimResult) MarshalJSON() ([]byte, error) {
+	edges := make([]jsonResultEdge, 0, len(r.MSTEdges))
+	for _, edge := range r.MSTEdges {
+		edges = append(edges, jsonResultEdge{jsonNodeId(edge.List[0]), jsonNodeId(edge.List[1]), jsonWeight(edge)})
+	}
+	return json.Marshal(struct {
+		Edges       []jsonResultEdge `json:"edges"`
+		TotalWeight float64          `json:"totalWeight"`
+		IsConnected bool             `json:"isConnected"`
+	}{edges, r.TotalWeight, r.IsConnected})
+}
+
+// MarshalJSON returns the shortest paths as
+// {"start": "A", "hasNegativeCycle": false, "negativeCycleNodes": [], "distances": {"B": 3, ...}, "paths": {"B": ["A", "B"], ...}}.
+// A vertex without a path is not in "paths"
+func (r *BellmanFordResult) MarshalJSON() ([]byte, error) {
+	var start *string
+	if r.Start != nil {
+		id := jsonNodeId(r.Start)
+		start = &id
+	}
+
+	distances := make(map[string]interface{}, len(r.Distances))
+	paths := make(map[string][]string)
+	for node, distance := range r.Distances {
+		id := jsonNodeId(node)
+		distances[id] = jsonDistance(distance)
+		if math.IsInf(distance, 0) {
+			continue
+		}
+		if path := r.ReconstructPath(node); path != nil {
+			paths[id] = jsonNodeIds(path)
+		}
+	}
+
+	return json.Marshal(struct {
+		Start              *string                `json:"start"`
+		HasNegativeCycle   bool                   `json:"hasNegativeCycle"`
+		NegativeCycleNodes []string               `json:"negativeCycleNodes"`
+		Distances          map[string]interface{} `json:"distances"`
+		Paths              map[string][]string    `json:"paths"`
+	}{start, r.HasNegativeCycle, jsonNodeIds(r.NegativeCycleNodes), distances, paths})
+}
+
+type jsonFlowEdge struct {
+	From     string  `json:"from"`
+	To       string  `json:"to"`
+	Capacity float64 `json:"capacity"`
+	Flow     float64 `json:"flow"`
+}
+
+// MarshalJSON returns the max flow as
+// {"source": "s", "sink": "t", "maxFlow": 5, "flows": [{"from": "s", "to": "a", "capacity": 3, "flow": 2}, ...], "minCut": [...]}.
+// "flows" lists the edges of the network with a positive flow
+func (r *MaxFlowResult) MarshalJSON() ([]byte, error) {
+	flowEdges := make([]*FlowEdge, 0, len(r.Flow))
+	for edge, flow := range r.Flow {
+		if flow > 0 {
+			flowEdges = append(flowEdges, edge)
+		}
+	}
+	sortFlowEdges(flowEdges)
+	flows := make([]jsonFlowEdge, 0, len(flowEdges))
+	for _, edge := range flowEdges {
+		flows = append(flows, jsonFlowEdge{jsonNodeId(edge.From), jsonNodeId(edge.To), edge.Capacity, r.Flow[edge]})
+	}
+
+	cut := append([]*FlowEdge(nil), r.MinCut...)
+	sortFlowEdges(cut)
+	minCut := make([]jsonFlowEdge, 0, len(cut))
+	for _, edge := range cut {
+		minCut = append(minCut, jsonFlowEdge{jsonNodeId(edge.From), jsonNodeId(edge.To), edge.Capacity, r.Flow[edge]})
+	}
+
+	var source, sink *string
+	if r.Source != nil {
+		id := jsonNodeId(r.Source)
+		source = &id
+	}
+	if r.Sink != nil {
+		id := jsonNodeId(r.Sink)
+		sink = &id
+	}
+
+	return json.Marshal(struct {
+		Source  *string        `json:"source"`
+		Sink    *string        `json:"sink"`
+		MaxFlow float64        `json:"maxFlow"`
+		Flows   []jsonFlowEdge `json:"flows"`
+		MinCut  []jsonFlowEdge `json:"minCut"`
+	}{source, sink, r.MaxFlowValue, flows, minCut})
+}
+
+// sortFlowEdges sorts the edges of a flow network by the ids of their ends
+func sortFlowEdges(edges []*FlowEdge) {
+	sort.Slice(edges, func(i, j int) bool {
+		if edges[i].From.Id != edges[j].From.Id {
+			return edges[i].From.Id < edges[j].From.Id
+		}
+		return edges[i].To.Id < edges[j].To.Id
+	})
+}
+
+// DistancesJSON returns the distances found by FloydWarshallSimple between the vertices
+// in the given order as {"nodes": ["A", "B"], "hasNegativeCycle": false, "distances": [[0, 3], [null, 0]]},
+// where distances[i][j] is the distance from nodes[i] to nodes[j]
+func DistancesJSON(nodes []*Node, distances map[*Node]map[*Node]float64, hasNegativeCycle bool) ([]byte, error) {
+	matrix := make([][]interface{}, 0, len(nodes))
+	for _, u := range nodes {
+		row := make([]interface{}, 0, len(nodes))
+		for _, v := range nodes {
+			d, ok := distances[u][v]
+			if !ok {
+				d = math.Inf(1)
+			}
+			row = append(row, jsonDistance(d))
+		}
+		matrix = append(matrix, row)
+	}
+
+	return json.Marshal(struct {
+		Nodes            []string        `json:"nodes"`
+		HasNegativeCycle bool            `json:"hasNegativeCycle"`
+		Distances        [][]interface{} `json:"distances"`
+	}{jsonNodeIds(nodes), hasNegativeCycle, matrix})
+}
+
+// jsonNodeIds returns the JSON ids of the vertices
+func jsonNodeIds(nodes []*Node) []string {
+	ids := make([]string, 0, len(nodes))
+	for _, node := range nodes {
+		ids = append(ids, jsonNodeId(node))
+	}
+	return ids
+}
@@ -0,0 +1,134 @@
+package graph
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestJSONKeepsIds(t *testing.T) {
+	g := GraphConstructor(true, true)
+	AddEdgeBetweenNodes(g, "A", "B", 1)
+	AddEdgeBetweenNodes(g, "B", "C", 2)
+	AddEdgeBetweenNodes(g, "C", "A", 3)
+	RemoveVertex(g, FindNodeByValue(g, "A"))
+
+	back := decodeString(t, encodeString(t, g, "json"), "json")
+	for _, node := range g.Nodes() {
+		if got := FindNodeByValue(back, node.Value).Id; got != node.Id {
+			t.Errorf("vertex %v: id %d, want %d", node.Value, got, node.Id)
+		}
+		if back.NodeById(node.Id) == nil {
+			t.Errorf("no vertex with the id %d", node.Id)
+		}
+	}
+	for _, edge := range GetAllEdges(g) {
+		if got := back.EdgeById(edge.Id); got == nil || got.List[0].Value != edge.List[0].Value {
+			t.Errorf("no edge %v->%v with the id %d", edge.List[0].Value, edge.List[1].Value, edge.Id)
+		}
+	}
+
+	// The ids of the removed vertex A and its edges are not given out again
+	node := NodeConstructor("D")
+	AddVertex(back, node)
+	if node.Id != 3 {
+		t.Errorf("the new vertex has the id %d, want 3", node.Id)
+	}
+	edge, _ := addEdge(back, node, FindNodeByValue(back, "B"), 0, nil)
+	if edge.Id != 3 {
+		t.Errorf("the new edge has the id %d, want 3", edge.Id)
+	}
+}
+
+func TestJSONIdsWithoutNumbers(t *testing.T) {
+	g := decodeString(t, `{
+  "nodes": [{"id": "A", "number": 5}, {"id": "B"}, {"id": "C", "number": 1}],
+  "edges": [{"from": "A", "to": "B"}, {"id": 4, "from": "B", "to": "C"}],
+  "nextEdgeId": 7
+}`, "json")
+
+	want := map[string]uint32{"A": 5, "B": 6, "C": 1}
+	for value, id := range want {
+		if got := FindNodeByValue(g, value).Id; got != id {
+			t.Errorf("vertex %s: id %d, want %d", value, got, id)
+		}
+	}
+	if edge := g.EdgeById(7); edge == nil || edge.List[0].Value != "A" {
+		t.Errorf("the edge without an id did not get the id 7 after nextEdgeId")
+	}
+	if edge := g.EdgeById(4); edge == nil || edge.List[0].Value != "B" {
+		t.Errorf("the edge B->C lost its id 4")
+	}
+}
+
+func TestJSONRoundTrip(t *testing.T) {
+	weighted := MultigraphConstructor(true, true)
+	AddEdgeBetweenNodes(weighted, "A", "B", 2.5)
+	AddEdgeBetweenNodes(weighted, "A", "B", -1)
+	AddEdgeBetweenNodes(weighted, "New York", "A", 0)
+	weighted.Nodes()[0].Attributes.Set("color", "red")
+	GetAllEdges(weighted)[1].Attributes.Set("odd key", "a \"quoted\" \\ value")
+	SetFlowTerminals(weighted, FindNodeByValue(weighted, "New York"), FindNodeByValue(weighted, "B"))
+
+	mixed := GraphConstructor(true, false)
+	mixed.SetMixed(true)
+	AddEdgeBetweenNodes(mixed, "A", "B", 0)
+	AddNonOrientedEdgeBetweenNodes(mixed, "B", "C", 0)
+	AddVertex(mixed, NodeConstructor("D"))
+
+	tests := []struct {
+		name string
+		g    *GraphInfo
+	}{
+		{"weighted multigraph with terminals", weighted},
+		{"mixed with an isolated vertex", mixed},
+		{"empty", GraphConstructor(false, false)},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			encoded := encodeString(t, test.g, "json")
+			back := decodeString(t, encoded, "json")
+			assertSameGraph(t, test.g, back)
+			if got, want := encodeString(t, back, "text"), encodeString(t, test.g, "text"); got != want {
+				t.Errorf("the graph changed after a round trip through\n%s\ngot\n%s\nwant\n%s", encoded, got, want)
+			}
+			source, sink := FlowTerminals(test.g)
+			backSource, backSink := FlowTerminals(back)
+			if (source == nil) != (backSource == nil) || (sink == nil) != (backSink == nil) ||
+				source != nil && source.Value != backSource.Value || sink != nil && sink.Value != backSink.Value {
+				t.Errorf("the flow terminals %v, %v became %v, %v", source, sink, backSource, backSink)
+			}
+		})
+	}
+}
+
+func TestJSONDecodeErrors(t *testing.T) {
+	tests := []struct {
+		name string
+		json string
+		want string
+	}{
+		{"syntax error", "{\n  \"nodes\": [{\"id\": \"A\"},]\n}", "<input>:2:"},
+		{"not an object", `[1, 2]`, "cannot unmarshal array"},
+		{"neither nodes nor edges", `{"oriented": true}`, "neither \"nodes\" nor \"edges\""},
+		{"duplicate vertex", `{"nodes": [{"id": "A"}, {"id": "A"}]}`, "node 2: duplicate vertex 'A'"},
+		{"unknown vertex", `{"nodes": [{"id": "A"}], "edges": [{"from": "A", "to": "B"}]}`, "edge 1: unknown vertex 'B'"},
+		{"duplicate vertex id", `{"nodes": [{"id": "A", "number": 1}, {"id": "B", "number": 1}]}`, "duplicate node id 1"},
+		{"duplicate edge id", `{"multigraph": true, "nodes": [{"id": "A"}], "edges": [{"id": 0, "from": "A", "to": "A"}, {"id": 0, "from": "A", "to": "A"}]}`,
+			"duplicate edge id 0"},
+		{"unknown source", `{"nodes": [{"id": "A"}], "source": "B"}`, "unknown vertex 'B'"},
+		{"workspace", `{"version": 1, "graphs": []}`, ErrWorkspace.Error()},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			_, err := Decode(strings.NewReader(test.json), "json")
+			if err == nil {
+				t.Fatalf("no error")
+			}
+			if !strings.Contains(err.Error(), test.want) {
+				t.Errorf("error %q, want %q", err, test.want)
+			}
+		})
+	}
+}
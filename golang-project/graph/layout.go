@@ -0,0 +1,300 @@
+package graph
+
+import (
+	"math"
+	"sort"
+)
+
+// Point is the position of a vertex in a drawing, in centimeters. The y axis goes up
+type Point struct {
+	X, Y float64
+}
+
+// Layout is the positions of the vertices of a graph in a drawing
+type Layout map[*Node]Point
+
+// vertexSpacing is the distance between the neighbouring vertices of CircularLayout
+const vertexSpacing = 1.6
+
+// CircularLayout places the vertices on a circle in the order they were added,
+// the first one on the top, clockwise. The circle grows with the number of vertices
+func CircularLayout(g *GraphInfo) Layout {
+	layout := make(Layout, len(g.nodes))
+	n := len(g.nodes)
+	if n == 1 {
+		layout[g.nodes[0]] = Point{}
+		return layout
+	}
+	radius := math.Max(1.5, float64(n)*vertexSpacing/(2*math.Pi))
+	for i, node := range g.nodes {
+		angle := math.Pi/2 - 2*math.Pi*float64(i)/float64(n)
+		layout[node] = Point{X: radius * math.Cos(angle), Y: radius * math.Sin(angle)}
+	}
+	return layout
+}
+
+// center returns the center of the bounding box of the layout
+func (l Layout) center() Point {
+	if len(l) == 0 {
+		return Point{}
+	}
+	minX, minY := math.Inf(1), math.Inf(1)
+	maxX, maxY := math.Inf(-1), math.Inf(-1)
+	for _, p := range l {
+		minX, maxX = math.Min(minX, p.X), math.Max(maxX, p.X)
+		minY, maxY = math.Min(minY, p.Y), math.Max(maxY, p.Y)
+	}
+	return Point{X: (minX + maxX) / 2, Y: (minY + maxY) / 2}
+}
+
+// The parameters of ForceDirectedLayout
+const (
+	forceIterations = 300
+	forceGravity    = 0.1 // the pull to the center that keeps the components together
+)
+
+// ForceDirectedLayout places the vertices by the Fruchterman-Reingold algorithm: the vertices push
+// each other apart and the edges pull their ends together, so that the edges have about the same length.
+// The directions of the edges are ignored. The layout is the same for the same graph
+func ForceDirectedLayout(g *GraphInfo) Layout {
+	layout := CircularLayout(g)
+	n := len(g.nodes)
+	if n < 2 {
+		return layout
+	}
+
+	positions := make([]Point, n)
+	index := make(map[*Node]int, n)
+	for i, node := range g.nodes {
+		positions[i] = layout[node]
+		index[node] = i
+	}
+	var pairs [][2]int
+	for _, edge := range GetAllEdges(g) {
+		if u, v := index[edge.List[0]], index[edge.List[1]]; u != v {
+			pairs = append(pairs, [2]int{u, v})
+		}
+	}
+
+	k := vertexSpacing // the length of an edge at rest
+	temperature := k * math.Sqrt(float64(n)) / 2
+	cooling := temperature / forceIterations
+	moves := make([]Point, n)
+	for iteration := 0; iteration < forceIterations; iteration++ {
+		for i := range moves {
+			moves[i] = Point{X: -positions[i].X * forceGravity, Y: -positions[i].Y * forceGravity}
+		}
+		for i := 0; i < n; i++ {
+			for j := i + 1; j < n; j++ {
+				dx, dy := positions[i].X-positions[j].X, positions[i].Y-positions[j].Y
+				distance := math.Max(math.Hypot(dx, dy), 0.01)
+				force := k * k / distance
+				moves[i].X += dx / distance * force
+				moves[i].Y += dy / distance * force
+				moves[j].X -= dx / distance * force
+				moves[j].Y -= dy / distance * force
+			}
+		}
+		for _, pair := range pairs {
+			u, v := pair[0], pair[1]
+			dx, dy := positions[u].X-positions[v].X, positions[u].Y-positions[v].Y
+			distance := math.Max(math.Hypot(dx, dy), 0.01)
+			force := distance * distance / k
+			moves[u].X -= dx / distance * force
+			moves[u].Y -= dy / distance * force
+			moves[v].X += dx / distance * force
+			moves[v].Y += dy / distance * force
+		}
+		for i, move := range moves {
+			if length := math.Hypot(move.X, move.Y); length > 0 {
+				step := math.Min(length, temperature)
+				positions[i].X += move.X / length * step
+				positions[i].Y += move.Y / length * step
+			}
+		}
+		temperature -= cooling
+	}
+
+	for i, node := range g.nodes {
+		layout[node] = positions[i]
+	}
+	return layout.centered()
+}
+
+// The parameters of LayeredLayout
+const (
+	layerSpacing = 1.6 // between the layers
+	layerSweeps  = 4   // the number of passes that order the vertices of the layers
+)
+
+// LayeredLayout draws the graph from the top to the bottom: every arc goes down to a later layer,
+// the vertices of a layer are ordered to cross fewer edges. Meant for DAGs: in a graph with cycles
+// some arcs go up. A non-oriented edge is drawn as if it was an arc from its first end
+func LayeredLayout(g *GraphInfo) Layout {
+	n := len(g.nodes)
+	index := make(map[*Node]int, n)
+	for i, node := range g.nodes {
+		index[node] = i
+	}
+	arcs, _ := layeredArcs(g, index)
+
+	// The layer of a vertex is the length of the longest path to it, the arcs are in a topological order
+	layers := make([]int, n)
+	successors := make([][]int, n)
+	predecessors := make([][]int, n)
+	inDegree := make([]int, n)
+	for _, arc := range arcs {
+		successors[arc[0]] = append(successors[arc[0]], arc[1])
+		predecessors[arc[1]] = append(predecessors[arc[1]], arc[0])
+		inDegree[arc[1]]++
+	}
+	var queue []int
+	for i := 0; i < n; i++ {
+		if inDegree[i] == 0 {
+			queue = append(queue, i)
+		}
+	}
+	for len(queue) > 0 {
+		u := queue[0]
+		queue = queue[1:]
+		for _, v := range successors[u] {
+			layers[v] = max(layers[v], layers[u]+1)
+			if inDegree[v]--; inDegree[v] == 0 {
+				queue = append(queue, v)
+			}
+		}
+	}
+
+	var rows [][]int
+	for i, layer := range layers {
+		for len(rows) <= layer {
+			rows = append(rows, nil)
+		}
+		rows[layer] = append(rows[layer], i)
+	}
+
+	// Barycenter ordering: a vertex goes to the mean place of its neighbours in the adjacent layer,
+	// going down and then up
+	place := make([]float64, n)
+	setPlaces := func(row []int) {
+		for i, v := range row {
+			place[v] = float64(i) - float64(len(row)-1)/2
+		}
+	}
+	for _, row := range rows {
+		setPlaces(row)
+	}
+	orderBy := func(row []int, neighbours [][]int) {
+		barycenter := make(map[int]float64, len(row))
+		for _, v := range row {
+			barycenter[v] = place[v]
+			if len(neighbours[v]) == 0 {
+				continue
+			}
+			sum := 0.0
+			for _, u := range neighbours[v] {
+				sum += place[u]
+			}
+			barycenter[v] = sum / float64(len(neighbours[v]))
+		}
+		sort.SliceStable(row, func(i, j int) bool { return barycenter[row[i]] < barycenter[row[j]] })
+		setPlaces(row)
+	}
+	for sweep := 0; sweep < layerSweeps; sweep++ {
+		for i := 1; i < len(rows); i++ {
+			orderBy(rows[i], predecessors)
+		}
+		for i := len(rows) - 2; i >= 0; i-- {
+			orderBy(rows[i], successors)
+		}
+	}
+
+	layout := make(Layout, n)
+	for layer, row := range rows {
+		for _, v := range row {
+			layout[g.nodes[v]] = Point{X: place[v] * vertexSpacing, Y: -float64(layer) * layerSpacing}
+		}
+	}
+	return layout.centered()
+}
+
+// layeredArcs returns the arcs between the indexes of the vertices without loops and with the arcs
+// that close cycles reversed, and whether there were such arcs
+func layeredArcs(g *GraphInfo, index map[*Node]int) ([][2]int, bool) {
+	n := len(g.nodes)
+	out := make([][]int, n)
+	var arcs [][2]int
+	for _, edge := range GetAllEdges(g) {
+		u, v := index[edge.List[0]], index[edge.List[1]]
+		if u != v {
+			out[u] = append(out[u], len(arcs))
+			arcs = append(arcs, [2]int{u, v})
+		}
+	}
+
+	// A depth-first search finds the arcs to the vertices on the stack
+	const (
+		unvisited = iota
+		onStack
+		done
+	)
+	state := make([]int, n)
+	reversed := false
+	var visit func(u int)
+	visit = func(u int) {
+		state[u] = onStack
+		for _, a := range out[u] {
+			v := arcs[a][1]
+			switch state[v] {
+			case unvisited:
+				visit(v)
+			case onStack:
+				arcs[a] = [2]int{v, u}
+				reversed = true
+			}
+		}
+		state[u] = done
+	}
+	for u := 0; u < n; u++ {
+		if state[u] == unvisited {
+			visit(u)
+		}
+	}
+	return arcs, reversed
+}
+
+// AutoLayout chooses the layout by the graph: LayeredLayout for a DAG, ForceDirectedLayout otherwise
+func AutoLayout(g *GraphInfo) Layout {
+	if isDAG(g) {
+		return LayeredLayout(g)
+	}
+	return ForceDirectedLayout(g)
+}
+
+// isDAG reports whether all the edges of g are oriented and there is no cycle
+func isDAG(g *GraphInfo) bool {
+	edges := GetAllEdges(g)
+	if len(edges) == 0 {
+		return false
+	}
+	index := make(map[*Node]int, len(g.nodes))
+	for i, node := range g.nodes {
+		index[node] = i
+	}
+	for _, edge := range edges {
+		if !edge.IsOriented() || edge.List[0] == edge.List[1] {
+			return false
+		}
+	}
+	_, reversed := layeredArcs(g, index)
+	return !reversed
+}
+
+// centered returns the layout moved so that the center of its bounding box is at the origin
+func (l Layout) centered() Layout {
+	c := l.center()
+	for node, p := range l {
+		l[node] = Point{X: p.X - c.X, Y: p.Y - c.Y}
+	}
+	return l
+}
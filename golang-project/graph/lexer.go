@@ -0,0 +1,161 @@
+package graph
+
+import (
+	"strconv"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+// The grammar of a line of the text format:
+//
+//	line       = "" | "#" comment | type | vertices | "ATTRIBUTES:" | "EDGES:" | attributes | edge
+//	type       = "TYPE:" { word }
+//	vertices   = "VERTICES:" [ identifier { "," identifier } ]
+//	attributes = identifier block                                  (in the ATTRIBUTES section)
+//	edge       = identifier ( "->" | "-" ) identifier [ ":" weight ] [ block ]
+//	           | identifier identifier [ weight ] [ block ]
+//	identifier = bare | quoted
+//	bare       = one or more characters other than spaces and , : [ ] " - < >
+//	quoted     = a Go string literal: "New-York", "say \"hi\"", "A, B"
+//	weight     = a number: 5, -3, +2.5, 1e-9, 3/4 (for Rational weights)
+//	block      = "[" key "=" value { "," key "=" value } "]"      (see parseAttributes)
+
+// tokenKind is the kind of a token of a line
+type tokenKind int
+
+const (
+	tokenEnd        tokenKind = iota // the end of the line
+	tokenIdentifier                  // a vertex, the text is unquoted
+	tokenArrow                       // ->
+	tokenDash                        // -
+	tokenColon                       // :
+	tokenComma                       // ,
+	tokenWeight                      // read by lexer.weight only
+	tokenBlock                       // an attribute block, the text is the inside of the brackets
+)
+
+// token is a piece of a line
+type token struct {
+	kind   tokenKind
+	text   string
+	column int // of the first byte of the token, starting with 1
+}
+
+// lexer splits a line into tokens. What a token is depends on what the parser expects,
+// so weights are read by a separate method
+type lexer struct {
+	line string
+	pos  int
+}
+
+// specialRunes can not be a part of a bare identifier
+const specialRunes = ",:[]\"-<>"
+
+func isBareRune(r rune) bool {
+	return !unicode.IsSpace(r) && !strings.ContainsRune(specialRunes, r)
+}
+
+func (l *lexer) skipSpaces() {
+	for l.pos < len(l.line) {
+		r, size := utf8.DecodeRuneInString(l.line[l.pos:])
+		if !unicode.IsSpace(r) {
+			return
+		}
+		l.pos += size
+	}
+}
+
+// next returns the next token of the line
+func (l *lexer) next() (token, error) {
+	l.skipSpaces()
+	start := l.pos
+	column := start + 1
+	if start == len(l.line) {
+		return token{kind: tokenEnd, column: column}, nil
+	}
+
+	rest := l.line[start:]
+	switch {
+	case strings.HasPrefix(rest, "->"):
+		l.pos += 2
+		return token{kind: tokenArrow, text: "->", column: column}, nil
+	case rest[0] == '-':
+		l.pos++
+		return token{kind: tokenDash, text: "-", column: column}, nil
+	case rest[0] == ':':
+		l.pos++
+		return token{kind: tokenColon, text: ":", column: column}, nil
+	case rest[0] == ',':
+		l.pos++
+		return token{kind: tokenComma, text: ",", column: column}, nil
+	case rest[0] == '"':
+		quoted, err := strconv.QuotedPrefix(rest)
+		if err != nil {
+			return token{}, errorAt(column, "unterminated quoted identifier")
+		}
+		value, _ := strconv.Unquote(quoted)
+		l.pos += len(quoted)
+		return token{kind: tokenIdentifier, text: value, column: column}, nil
+	case rest[0] == '[':
+		// The attribute block is the last thing in the line
+		block := strings.TrimRightFunc(rest, unicode.IsSpace)
+		if !strings.HasSuffix(block, "]") {
+			return token{}, errorAt(column, "attributes must end with ']'")
+		}
+		l.pos = len(l.line)
+		return token{kind: tokenBlock, text: block[1 : len(block)-1], column: column}, nil
+	}
+
+	for l.pos < len(l.line) {
+		r, size := utf8.DecodeRuneInString(l.line[l.pos:])
+		if !isBareRune(r) {
+			break
+		}
+		l.pos += size
+	}
+	if l.pos == start {
+		r, _ := utf8.DecodeRuneInString(rest)
+		return token{}, errorAt(column, "unexpected '%c'", r)
+	}
+	return token{kind: tokenIdentifier, text: l.line[start:l.pos], column: column}, nil
+}
+
+// peek returns the next token without moving past it
+func (l *lexer) peek() (token, error) {
+	pos := l.pos
+	tok, err := l.next()
+	l.pos = pos
+	return tok, err
+}
+
+// weight reads a weight: everything up to a space or an attribute block.
+// Returns a tokenEnd token if there is no weight
+func (l *lexer) weight() token {
+	l.skipSpaces()
+	start := l.pos
+	for l.pos < len(l.line) {
+		r, size := utf8.DecodeRuneInString(l.line[l.pos:])
+		if unicode.IsSpace(r) || r == '[' {
+			break
+		}
+		l.pos += size
+	}
+	if l.pos == start {
+		return token{kind: tokenEnd, column: start + 1}
+	}
+	return token{kind: tokenWeight, text: l.line[start:l.pos], column: start + 1}
+}
+
+// quoteIdentifier returns s as an identifier of the text format, quoted if it can not be written bare
+func quoteIdentifier(s string) string {
+	if s == "" || strings.HasPrefix(s, "#") || !utf8.ValidString(s) {
+		return strconv.Quote(s)
+	}
+	for _, r := range s {
+		if !isBareRune(r) || !unicode.IsPrint(r) {
+			return strconv.Quote(s)
+		}
+	}
+	return s
+}
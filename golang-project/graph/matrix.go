@@ -0,0 +1,701 @@
+package graph
+
+import (
+	"bytes"
+	"encoding/csv"
+	"fmt"
+	"io"
+	"math"
+	"strconv"
+	"strings"
+	"text/tabwriter"
+	"unicode"
+	"unicode/utf8"
+)
+
+// Reading and writing graphs as adjacency and incidence matrices, CSV (',' or ';') or whitespace separated.
+// The first row and the first column can hold the values of the vertices (the names of the edges
+// in the first row of an incidence matrix), see MatrixOptions.Header.
+// Lines starting with '#' are comments. A matrix without rows can not be read.
+//
+// An adjacency matrix has a row and a column for every vertex. A cell is the weight of the edge
+// from the row to the column or, in an unweighted graph, the number of such edges.
+// The matrix of a non-oriented graph is symmetric, a loop is counted once.
+//
+// An incidence matrix has a row for every vertex and a column for every edge. An arc has w at its start
+// and -w at its end, a non-oriented edge has w at both ends, a loop has w in its only cell,
+// w being the weight of the edge or 1 in an unweighted graph (2 for a loop). So a negative or zero weight
+// can not be written in an incidence matrix. A column with both kinds of edges makes the graph mixed
+
+// NoEdge is how a missing edge is written in a matrix
+type NoEdge int
+
+const (
+	NoEdgeZero  NoEdge = iota // 0
+	NoEdgeInf                 // INF, so that 0 can be a weight
+	NoEdgeEmpty               // an empty cell
+)
+
+// MatrixOptions describe the layout of an adjacency or an incidence matrix
+type MatrixOptions struct {
+	Oriented  bool   // reading: the edges are oriented, a non-oriented adjacency matrix must be symmetric
+	Weighted  bool   // reading: the cells are weights, otherwise they are numbers of edges
+	NoEdge    NoEdge // how a missing edge is written. When reading, empty and INF cells are always missing edges
+	Separator rune   // writing: ',' or ';' for CSV, 0 for columns aligned with spaces. Reading detects it
+	Header    bool   // the values of the vertices (and the names of the edges) are in the first column and row.
+	// Without it the reader still finds the headers whose cells are not numbers
+}
+
+// AdjacencyMatrixConstructor reads a graph from the adjacency matrix file at path.
+// Returns a *ParseError if a cell can not be read
+func AdjacencyMatrixConstructor(path string, options MatrixOptions) (*GraphInfo, error) {
+	return readFileWith(path, func(graph *GraphInfo, r io.Reader, name string) error {
+		return decodeAdjacencyMatrix(graph, r, name, options)
+	})
+}
+
+// IncidenceMatrixConstructor reads a graph from the incidence matrix file at path.
+// Returns a *ParseError if a cell can not be read
+func IncidenceMatrixConstructor(path string, options MatrixOptions) (*GraphInfo, error) {
+	return readFileWith(path, func(graph *GraphInfo, r io.Reader, name string) error {
+		return decodeIncidenceMatrix(graph, r, name, options)
+	})
+}
+
+// WriteAdjacencyMatrix saves the graph as an adjacency matrix with the vertices in the order they were added.
+// Non-oriented edges of a mixed graph are written in both directions.
+// Parallel edges of a weighted multigraph can not be written, nor the weight 0 with NoEdgeZero
+func WriteAdjacencyMatrix(graph *GraphInfo, path string, options MatrixOptions) error {
+	return WriteFileWith(path, func(w io.Writer) error {
+		return EncodeAdjacencyMatrix(graph, w, options)
+	})
+}
+
+// WriteIncidenceMatrix saves the graph as an incidence matrix with the vertices in the order they were added
+// and the edges in the order of GetAllEdges
+func WriteIncidenceMatrix(graph *GraphInfo, path string, options MatrixOptions) error {
+	return WriteFileWith(path, func(w io.Writer) error {
+		return EncodeIncidenceMatrix(graph, w, options)
+	})
+}
+
+// matrixCell is a cell of a matrix file
+type matrixCell struct {
+	text   string
+	line   int
+	column int
+}
+
+func (c matrixCell) errorf(format string, args ...interface{}) *ParseError {
+	err := errorAt(c.column, format, args...)
+	err.Line = c.line
+	return err
+}
+
+// matrix is a matrix read from a file without its headers
+type matrix struct {
+	rows      [][]matrixCell
+	rowNames  []matrixCell // the first column, nil if there is none
+	colNames  []matrixCell // the first row, nil if there is none
+	lastLine  int
+	separator rune
+}
+
+// readMatrix reads the rows of a matrix and splits off its headers: the first row and column
+// if header is set, the ones with cells that are not numbers otherwise. Returns an error if there are no rows
+func readMatrix(src []byte, header bool) (*matrix, *ParseError) {
+	m := &matrix{}
+	var rows [][]matrixCell
+	var err *ParseError
+	if m.separator = detectSeparator(src); m.separator != 0 {
+		rows, err = readCSVRows(src, m.separator)
+	} else {
+		rows, err = readSpaceRows(src)
+	}
+	if err != nil {
+		return nil, err
+	}
+	if len(rows) == 0 {
+		return nil, errorAtCell(nil, 1, "no rows of the matrix found")
+	}
+	m.lastLine = rows[len(rows)-1][0].line
+
+	// The header row has a cell that is not a number, its first cell may be the empty corner.
+	// Separated by spaces the corner is left out, so the header is shorter than the rows
+	cornerLeftOut := len(rows) == 1 || len(rows[0]) < len(rows[1])
+	if header || hasNonNumber(rows[0][1:]) || (cornerLeftOut && hasNonNumber(rows[0])) {
+		m.colNames, rows = rows[0], rows[1:]
+	}
+	if len(rows) == 0 {
+		return nil, errorAtCell(m.colNames, m.lastLine, "the matrix has a header but no rows")
+	}
+	hasRowNames := header
+	for _, row := range rows {
+		if hasNonNumber(row[:1]) {
+			hasRowNames = true
+			break
+		}
+	}
+	if hasRowNames {
+		for _, row := range rows {
+			m.rowNames = append(m.rowNames, row[0])
+		}
+		for i := range rows {
+			rows[i] = rows[i][1:]
+		}
+		// The corner: the header row has one more cell than the rows of numbers
+		if len(rows) > 0 && len(m.colNames) == len(rows[0])+1 {
+			m.colNames = m.colNames[1:]
+		}
+	}
+	m.rows = rows
+
+	for _, row := range m.rows {
+		if len(row) != len(m.rows[0]) {
+			return nil, errorAtCell(row, m.lastLine, "expected %d cells in the row, found %d", len(m.rows[0]), len(row))
+		}
+	}
+	if m.colNames != nil && len(m.rows) > 0 && len(m.colNames) != len(m.rows[0]) {
+		return nil, errorAtCell(m.colNames, m.lastLine, "expected %d names in the header, found %d", len(m.rows[0]), len(m.colNames))
+	}
+	return m, nil
+}
+
+// errorAtCell returns an error at the first cell of row
+func errorAtCell(row []matrixCell, line int, format string, args ...interface{}) *ParseError {
+	if len(row) == 0 {
+		err := errorAt(1, format, args...)
+		err.Line = line
+		return err
+	}
+	return row[0].errorf(format, args...)
+}
+
+// detectSeparator returns the separator of CSV cells of the first row: ',' or ';', 0 if the cells are separated by spaces
+func detectSeparator(src []byte) rune {
+	for _, line := range strings.Split(string(src), "\n") {
+		line = strings.TrimSpace(line)
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		switch {
+		case strings.ContainsRune(line, ','):
+			return ','
+		case strings.ContainsRune(line, ';'):
+			return ';'
+		}
+		return 0
+	}
+	return 0
+}
+
+func readCSVRows(src []byte, separator rune) ([][]matrixCell, *ParseError) {
+	reader := csv.NewReader(bytes.NewReader(src))
+	reader.Comma = separator
+	reader.Comment = '#'
+	reader.FieldsPerRecord = -1
+	reader.TrimLeadingSpace = true
+
+	var rows [][]matrixCell
+	for {
+		record, err := reader.Read()
+		if err == io.EOF {
+			return rows, nil
+		}
+		if err != nil {
+			if csvErr, ok := err.(*csv.ParseError); ok {
+				return nil, &ParseError{Line: csvErr.Line, Column: csvErr.Column, Msg: csvErr.Err.Error()}
+			}
+			return nil, &ParseError{Msg: err.Error()}
+		}
+		row := make([]matrixCell, len(record))
+		for i, text := range record {
+			line, column := reader.FieldPos(i)
+			row[i] = matrixCell{text: strings.TrimSpace(text), line: line, column: column}
+		}
+		rows = append(rows, row)
+	}
+}
+
+func readSpaceRows(src []byte) ([][]matrixCell, *ParseError) {
+	var rows [][]matrixCell
+	for i, line := range strings.Split(string(src), "\n") {
+		trimmed := strings.TrimSpace(line)
+		if trimmed == "" || strings.HasPrefix(trimmed, "#") {
+			continue
+		}
+		row, err := splitSpaceCells(line, i+1)
+		if err != nil {
+			return nil, err
+		}
+		rows = append(rows, row)
+	}
+	return rows, nil
+}
+
+// splitSpaceCells splits a line into cells separated by spaces, a cell can be a quoted Go string
+func splitSpaceCells(line string, lineNumber int) ([]matrixCell, *ParseError) {
+	var cells []matrixCell
+	pos := 0
+	for pos < len(line) {
+		r, size := utf8.DecodeRuneInString(line[pos:])
+		if unicode.IsSpace(r) {
+			pos += size
+			continue
+		}
+		cell := matrixCell{line: lineNumber, column: pos + 1}
+		if r == '"' {
+			quoted, err := strconv.QuotedPrefix(line[pos:])
+			if err != nil {
+				return nil, cell.errorf("unterminated quoted name")
+			}
+			cell.text, _ = strconv.Unquote(quoted)
+			pos += len(quoted)
+		} else {
+			end := strings.IndexFunc(line[pos:], unicode.IsSpace)
+			if end < 0 {
+				end = len(line) - pos
+			}
+			cell.text = line[pos : pos+end]
+			pos += end
+		}
+		cells = append(cells, cell)
+	}
+	return cells, nil
+}
+
+// isNoEdgeText reports whether the text of a cell always means a missing edge
+func isNoEdgeText(text string) bool {
+	switch strings.ToLower(text) {
+	case "", "-", "inf", "+inf", "infinity", "∞":
+		return true
+	}
+	return false
+}
+
+// hasNoEdgeCells reports whether a cell of the matrix in src, not counting its headers,
+// is a missing edge other than 0, e.g. INF or an empty cell
+func hasNoEdgeCells(src []byte) bool {
+	m, err := readMatrix(src, false)
+	if err != nil {
+		return false
+	}
+	for _, row := range m.rows {
+		for _, cell := range row {
+			if isNoEdgeText(cell.text) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// isMatrixNumber reports whether the text of a cell is a number or a missing edge, not a name
+func isMatrixNumber(text string) bool {
+	if isNoEdgeText(text) {
+		return true
+	}
+	if _, err := strconv.ParseFloat(text, 64); err == nil {
+		return true
+	}
+	_, err := ParseRational(text)
+	return err == nil
+}
+
+func hasNonNumber(cells []matrixCell) bool {
+	for _, cell := range cells {
+		if !isMatrixNumber(cell.text) {
+			return true
+		}
+	}
+	return false
+}
+
+// addMatrixVertices adds a vertex for every name, or the vertices 1..n if there are no names
+func addMatrixVertices(graph *GraphInfo, names []matrixCell, n int) ([]*Node, *ParseError) {
+	nodes := make([]*Node, 0, n)
+	for i := 0; i < n; i++ {
+		cell := matrixCell{text: strconv.Itoa(i + 1), line: 1, column: 1}
+		if names != nil {
+			cell = names[i]
+		}
+		value, err := graph.ParseValue(cell.text)
+		if err != nil {
+			return nil, cell.errorf("%v", err)
+		}
+		node := NodeConstructor(value)
+		if err := AddVertex(graph, node); err != nil {
+			return nil, cell.errorf("duplicate vertex '%s'", cell.text)
+		}
+		nodes = append(nodes, node)
+	}
+	return nodes, nil
+}
+
+// matrixEntry is a cell of the matrix read as a weight or a number of edges
+type matrixEntry struct {
+	weight float64
+	exact  interface{}
+	count  int // the number of edges of an unweighted graph, the sign of the weight of a weighted one
+}
+
+// readEntry reads a cell, a missing edge has count 0
+func readEntry(graph *GraphInfo, cell matrixCell, options MatrixOptions) (matrixEntry, *ParseError) {
+	if isNoEdgeText(cell.text) {
+		return matrixEntry{}, nil
+	}
+	if !options.Weighted {
+		count, err := strconv.Atoi(cell.text)
+		if err != nil {
+			return matrixEntry{}, cell.errorf("invalid number of edges '%s'", cell.text)
+		}
+		return matrixEntry{count: count}, nil
+	}
+
+	weight, exact, err := graph.ParseWeight(cell.text)
+	if err != nil {
+		return matrixEntry{}, cell.errorf("%v", err)
+	}
+	entry := matrixEntry{weight: weight, exact: exact}
+	switch {
+	case weight > 0:
+		entry.count = 1
+	case weight < 0:
+		entry.count = -1
+	}
+	return entry, nil
+}
+
+// decodeAdjacencyMatrix reads an adjacency matrix into graph. name is the name of the file for the errors
+func decodeAdjacencyMatrix(graph *GraphInfo, r io.Reader, name string, options MatrixOptions) error {
+	src, err := io.ReadAll(r)
+	if err != nil {
+		return fmt.Errorf("Error reading file: %v", err)
+	}
+	if parseErr := readAdjacencyMatrix(graph, src, options); parseErr != nil {
+		parseErr.Path = name
+		return parseErr
+	}
+	return nil
+}
+
+func readAdjacencyMatrix(graph *GraphInfo, src []byte, options MatrixOptions) *ParseError {
+	m, err := readMatrix(src, options.Header)
+	if err != nil {
+		return err
+	}
+	n := len(m.rows)
+	if n > 0 && len(m.rows[0]) != n {
+		return errorAtCell(m.rows[0], m.lastLine, "the adjacency matrix must be square, it has %d rows and %d columns", n, len(m.rows[0]))
+	}
+	names := m.colNames
+	if m.rowNames != nil {
+		if names != nil {
+			for i := range names {
+				if names[i].text != m.rowNames[i].text {
+					return m.rowNames[i].errorf("the row '%s' and the column '%s' must be the same vertex", m.rowNames[i].text, names[i].text)
+				}
+			}
+		}
+		names = m.rowNames
+	}
+
+	graph.isOriented, graph.isWeighted = options.Oriented, options.Weighted
+	nodes, err := addMatrixVertices(graph, names, n)
+	if err != nil {
+		return err
+	}
+
+	// isNone reports whether the text of a cell is a missing edge
+	isNone := func(text string) bool {
+		return isNoEdgeText(text) || (text == "0" && (!options.Weighted || options.NoEdge == NoEdgeZero))
+	}
+	for i, row := range m.rows {
+		for j, cell := range row {
+			if !options.Oriented && j < i {
+				continue // the lower half must be the same as the upper one
+			}
+			if mirror := m.rows[j][i]; !options.Oriented && mirror.text != cell.text && !(isNone(mirror.text) && isNone(cell.text)) {
+				return mirror.errorf("the matrix of a non-oriented graph must be symmetric: '%s' and '%s'", mirror.text, cell.text)
+			}
+			if isNone(cell.text) {
+				continue
+			}
+			entry, err := readEntry(graph, cell, options)
+			if err != nil {
+				return err
+			}
+
+			count := entry.count
+			if options.Weighted {
+				count = 1
+			} else if count < 0 {
+				return cell.errorf("negative number of edges '%s'", cell.text)
+			}
+			if count > 1 {
+				graph.isMultigraph = true
+			}
+			for k := 0; k < count; k++ {
+				if _, err := addOrientedOrNot(graph, nodes[i], nodes[j], entry.weight, entry.exact, options.Oriented); err != nil {
+					return cell.errorf("%v", err)
+				}
+			}
+		}
+	}
+	return nil
+}
+
+// decodeIncidenceMatrix reads an incidence matrix into graph. name is the name of the file for the errors
+func decodeIncidenceMatrix(graph *GraphInfo, r io.Reader, name string, options MatrixOptions) error {
+	src, err := io.ReadAll(r)
+	if err != nil {
+		return fmt.Errorf("Error reading file: %v", err)
+	}
+	if parseErr := readIncidenceMatrix(graph, src, options); parseErr != nil {
+		parseErr.Path = name
+		return parseErr
+	}
+	return nil
+}
+
+func readIncidenceMatrix(graph *GraphInfo, src []byte, options MatrixOptions) *ParseError {
+	m, err := readMatrix(src, options.Header)
+	if err != nil {
+		return err
+	}
+	graph.isOriented, graph.isWeighted = options.Oriented, options.Weighted
+	nodes, err := addMatrixVertices(graph, m.rowNames, len(m.rows))
+	if err != nil {
+		return err
+	}
+
+	hasOriented, hasNonOriented := false, false
+	for j := range m.rows[0] {
+		var ends []int // the rows of the vertices of the edge
+		var entries []matrixEntry
+		for i, row := range m.rows {
+			if row[j].text == "0" {
+				continue
+			}
+			entry, err := readEntry(graph, row[j], options)
+			if err != nil {
+				return err
+			}
+			if entry.count != 0 {
+				ends = append(ends, i)
+				entries = append(entries, entry)
+			}
+		}
+		top := m.rows[0][j]
+		if m.colNames != nil {
+			top = m.colNames[j]
+		}
+
+		var edge *Edge
+		switch len(ends) {
+		case 0:
+			return top.errorf("the column %d has no vertices", j+1)
+		case 1: // a loop
+			loop := nodes[ends[0]]
+			edge = addImportedEdge(graph, loop, loop, entries[0].weight, entries[0].exact, options.Oriented)
+		case 2:
+			a, b := entries[0], entries[1]
+			sameWeight := math.Abs(a.weight) == math.Abs(b.weight)
+			if !options.Weighted {
+				sameWeight = a.count*a.count == 1 && b.count*b.count == 1
+			}
+			if !sameWeight {
+				return top.errorf("the column %d must have the same weight at both ends", j+1)
+			}
+			from, to := nodes[ends[0]], nodes[ends[1]]
+			oriented := a.count != b.count
+			if oriented && a.count < 0 {
+				from, to = to, from
+				a = b
+			}
+			hasOriented = hasOriented || oriented
+			hasNonOriented = hasNonOriented || !oriented
+			edge = addImportedEdge(graph, from, to, a.weight, a.exact, oriented)
+		default:
+			return top.errorf("the column %d has %d vertices, an edge has at most 2", j+1, len(ends))
+		}
+		if m.colNames != nil && m.colNames[j].text != edgeName(graph, edge) {
+			edge.Attributes.Set("label", m.colNames[j].text)
+		}
+	}
+
+	if hasOriented {
+		graph.isOriented = true
+		graph.isMixed = hasNonOriented || graph.isMixed
+	} else if hasNonOriented && options.Oriented {
+		graph.isMixed = true
+	}
+	return nil
+}
+
+// noEdgeText returns the text of a missing edge
+func noEdgeText(noEdge NoEdge) string {
+	switch noEdge {
+	case NoEdgeInf:
+		return "INF"
+	case NoEdgeEmpty:
+		return ""
+	}
+	return "0"
+}
+
+// EncodeAdjacencyMatrix writes graph to w as an adjacency matrix, see WriteAdjacencyMatrix
+func EncodeAdjacencyMatrix(graph *GraphInfo, w io.Writer, options MatrixOptions) error {
+	index := make(map[*Node]int, len(graph.nodes))
+	for i, node := range graph.nodes {
+		index[node] = i
+	}
+	edges := make([][][]*Edge, len(graph.nodes)) // the edges from the row to the column
+	for i := range edges {
+		edges[i] = make([][]*Edge, len(graph.nodes))
+	}
+	for _, edge := range GetAllEdges(graph) {
+		from, to := index[edge.List[0]], index[edge.List[1]]
+		edges[from][to] = append(edges[from][to], edge)
+		if !edge.IsOriented() && from != to {
+			edges[to][from] = append(edges[to][from], edge)
+		}
+	}
+
+	var rows [][]string
+	if options.Header {
+		rows = append(rows, append([]string{""}, matrixNodeNames(graph)...))
+	}
+	for i, node := range graph.nodes {
+		var row []string
+		if options.Header {
+			row = append(row, fmt.Sprintf("%v", node.Value))
+		}
+		for j := range graph.nodes {
+			cell := edges[i][j]
+			switch {
+			case len(cell) == 0:
+				row = append(row, noEdgeText(options.NoEdge))
+			case !graph.isWeighted:
+				row = append(row, strconv.Itoa(len(cell)))
+			case len(cell) > 1:
+				return fmt.Errorf("%d parallel edges from '%v' to '%v' can not be written in a weighted adjacency matrix",
+					len(cell), node.Value, graph.nodes[j].Value)
+			case cell[0].Weight == 0 && options.NoEdge == NoEdgeZero:
+				return fmt.Errorf("the weight 0 of '%s' can not be written in an adjacency matrix where 0 is no edge",
+					edgeString(graph, cell[0]))
+			default:
+				row = append(row, formatWeight(cell[0]))
+			}
+		}
+		rows = append(rows, row)
+	}
+	return writeMatrix(w, rows, options)
+}
+
+// EncodeIncidenceMatrix writes graph to w as an incidence matrix, see WriteIncidenceMatrix
+func EncodeIncidenceMatrix(graph *GraphInfo, w io.Writer, options MatrixOptions) error {
+	edges := GetAllEdges(graph)
+	cells := make(map[*Node][]string, len(graph.nodes))
+	for _, node := range graph.nodes {
+		cells[node] = make([]string, len(edges))
+		for j := range edges {
+			cells[node][j] = noEdgeText(options.NoEdge)
+		}
+	}
+
+	var header []string
+	if options.Header {
+		header = append(header, "")
+	}
+	for j, edge := range edges {
+		header = append(header, edgeName(graph, edge))
+		value := "1"
+		if graph.isWeighted {
+			value = formatWeight(edge)
+			if edge.Weight == 0 || (edge.IsOriented() && edge.Weight < 0) {
+				return fmt.Errorf("the weight %s of '%s' can not be written in an incidence matrix", value, edgeString(graph, edge))
+			}
+		}
+
+		from, to := edge.List[0], edge.List[1]
+		switch {
+		case from == to && !graph.isWeighted:
+			cells[from][j] = "2"
+		case from == to:
+			cells[from][j] = value
+		case edge.IsOriented():
+			cells[from][j], cells[to][j] = value, "-"+value
+		default:
+			cells[from][j], cells[to][j] = value, value
+		}
+	}
+
+	var rows [][]string
+	if options.Header {
+		rows = append(rows, header)
+	}
+	for _, node := range graph.nodes {
+		var row []string
+		if options.Header {
+			row = append(row, fmt.Sprintf("%v", node.Value))
+		}
+		rows = append(rows, append(row, cells[node]...))
+	}
+	return writeMatrix(w, rows, options)
+}
+
+// edgeName returns the name of an edge in the header of an incidence matrix: its label or "A->B" / "A-B"
+func edgeName(graph *GraphInfo, edge *Edge) string {
+	if label, ok := graph.EdgeAttributes(edge).Get("label"); ok {
+		return label
+	}
+	arrow := "-"
+	if edge.IsOriented() {
+		arrow = "->"
+	}
+	return fmt.Sprintf("%v%s%v", edge.List[0].Value, arrow, edge.List[1].Value)
+}
+
+func matrixNodeNames(graph *GraphInfo) []string {
+	names := make([]string, 0, len(graph.nodes))
+	for _, node := range graph.nodes {
+		names = append(names, fmt.Sprintf("%v", node.Value))
+	}
+	return names
+}
+
+// writeMatrix writes the rows as CSV with the separator of options, or as columns aligned with spaces if it is 0
+func writeMatrix(w io.Writer, rows [][]string, options MatrixOptions) error {
+	if options.Separator != 0 {
+		writer := csv.NewWriter(w)
+		writer.Comma = options.Separator
+		if err := writer.WriteAll(rows); err != nil {
+			return err
+		}
+		return writer.Error()
+	}
+
+	writer := tabwriter.NewWriter(w, 0, 0, 1, ' ', tabwriter.AlignRight)
+	for i, row := range rows {
+		cells := make([]string, len(row))
+		for j, cell := range row {
+			cells[j] = quoteMatrixCell(cell)
+		}
+		if i == 0 && options.Header {
+			cells[0] = "" // the corner is left blank
+		}
+		if _, err := io.WriteString(writer, strings.Join(cells, "\t")+"\t\n"); err != nil {
+			return err
+		}
+	}
+	return writer.Flush()
+}
+
+// quoteMatrixCell quotes a cell separated by spaces if it is empty or has spaces or quotes
+func quoteMatrixCell(cell string) string {
+	if cell == "" || strings.ContainsAny(cell, "\"#") || strings.IndexFunc(cell, unicode.IsSpace) >= 0 {
+		return strconv.Quote(cell)
+	}
+	return cell
+}
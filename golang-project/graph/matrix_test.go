@@ -0,0 +1,175 @@
+package graph
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+)
+
+// matrixRoundTrip writes the graph as a matrix with the options and reads it back with them
+func matrixRoundTrip(t *testing.T, g *GraphInfo, options MatrixOptions, incidence bool) *GraphInfo {
+	t.Helper()
+	encode, decode := EncodeAdjacencyMatrix, decodeAdjacencyMatrix
+	if incidence {
+		encode, decode = EncodeIncidenceMatrix, decodeIncidenceMatrix
+	}
+
+	var buf bytes.Buffer
+	if err := encode(g, &buf, options); err != nil {
+		t.Fatalf("encode: %v", err)
+	}
+	back := GraphEmptyConstructor()
+	if err := decode(back, strings.NewReader(buf.String()), inputName, options); err != nil {
+		t.Fatalf("decode of\n%s: %v", buf.String(), err)
+	}
+	return back
+}
+
+func TestAdjacencyMatrixRoundTrip(t *testing.T) {
+	zeroWeights := GraphConstructor(true, true)
+	AddEdgeBetweenNodes(zeroWeights, "A", "B", 0)
+	AddEdgeBetweenNodes(zeroWeights, "B", "C", -2.5)
+	AddEdgeBetweenNodes(zeroWeights, "C", "C", 1e21)
+
+	undirected := MultigraphConstructor(false, false)
+	AddNonOrientedEdgeBetweenNodes(undirected, "A", "B", 0)
+	AddNonOrientedEdgeBetweenNodes(undirected, "A", "B", 0)
+	AddNonOrientedEdgeBetweenNodes(undirected, "B", "B", 0)
+	AddVertex(undirected, NodeConstructor("isolated"))
+
+	names := GraphConstructor(true, true)
+	AddEdgeBetweenNodes(names, "New York", "say \"hi\"", 3)
+
+	single := GraphConstructor(true, true)
+	AddEdgeBetweenNodes(single, "A", "A", 1)
+
+	tests := []struct {
+		name    string
+		g       *GraphInfo
+		options MatrixOptions
+	}{
+		{"zero weights with INF", zeroWeights, MatrixOptions{Oriented: true, Weighted: true, NoEdge: NoEdgeInf, Header: true}},
+		{"zero weights with empty cells", zeroWeights, MatrixOptions{Oriented: true, Weighted: true, NoEdge: NoEdgeEmpty, Separator: ';', Header: true}},
+		{"undirected multigraph", undirected, MatrixOptions{Header: true}},
+		{"undirected multigraph as CSV", undirected, MatrixOptions{Separator: ',', Header: true}},
+		{"quoted names", names, MatrixOptions{Oriented: true, Weighted: true, NoEdge: NoEdgeInf, Header: true}},
+		{"single vertex", single, MatrixOptions{Oriented: true, Weighted: true, Header: true}},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			assertSameGraph(t, test.g, matrixRoundTrip(t, test.g, test.options, false))
+		})
+	}
+}
+
+func TestAdjacencyMatrixFormat(t *testing.T) {
+	// The registered format finds the INF cells and reads the weight 0 as an edge
+	g := decodeString(t, `   A   B   C
+A INF   0 INF
+B INF INF   2
+C INF INF INF
+`, "adjacency")
+	if got, want := encodeString(t, g, "text"), `TYPE: DIRECTED WEIGHTED
+VERTICES: A,B,C
+EDGES:
+A->B: 0
+B->C: 2
+`; got != want {
+		t.Errorf("got\n%s\nwant\n%s", got, want)
+	}
+
+	// Without names the vertices are numbered
+	g = decodeString(t, "0 1\n0 0\n", "adjacency")
+	if got := values(g.Nodes()); len(got) != 2 || got[0] != "1" || got[1] != "2" {
+		t.Errorf("the vertices are %v, want [1 2]", got)
+	}
+}
+
+func TestIncidenceMatrixRoundTrip(t *testing.T) {
+	directed := GraphConstructor(true, true)
+	AddEdgeBetweenNodes(directed, "A", "B", 2.5)
+	AddEdgeBetweenNodes(directed, "B", "C", 1)
+	AddEdgeBetweenNodes(directed, "C", "C", 4)
+
+	undirected := MultigraphConstructor(false, false)
+	AddNonOrientedEdgeBetweenNodes(undirected, "A", "B", 0)
+	AddNonOrientedEdgeBetweenNodes(undirected, "A", "B", 0)
+	AddNonOrientedEdgeBetweenNodes(undirected, "B", "B", 0)
+
+	mixed := GraphConstructor(true, false)
+	mixed.SetMixed(true)
+	AddEdgeBetweenNodes(mixed, "A", "B", 0)
+	AddNonOrientedEdgeBetweenNodes(mixed, "B", "C", 0)
+
+	single := GraphConstructor(true, true)
+	AddEdgeBetweenNodes(single, "A", "B", 3)
+
+	tests := []struct {
+		name    string
+		g       *GraphInfo
+		options MatrixOptions
+	}{
+		{"directed", directed, MatrixOptions{Oriented: true, Weighted: true, Header: true}},
+		{"directed as CSV", directed, MatrixOptions{Oriented: true, Weighted: true, Separator: ',', Header: true}},
+		{"undirected multigraph", undirected, MatrixOptions{Header: true}},
+		{"mixed", mixed, MatrixOptions{Oriented: true, Header: true}},
+		{"single edge", single, MatrixOptions{Oriented: true, Weighted: true, Header: true}},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			back := matrixRoundTrip(t, test.g, test.options, true)
+			// The reader finds out that the graph is a multigraph only from the parallel edges
+			back.isMultigraph = test.g.isMultigraph
+			assertSameGraph(t, test.g, back)
+		})
+	}
+
+	// The registered format reads the header without the corner
+	single = decodeString(t, encodeString(t, single, "incidence"), "incidence")
+	if got := len(GetAllEdges(single)); got != 1 {
+		t.Errorf("%d edges, want 1", got)
+	}
+}
+
+func TestMatrixDecodeErrors(t *testing.T) {
+	tests := []struct {
+		name   string
+		format string
+		matrix string
+		want   string
+	}{
+		{"empty", "adjacency", "", "no rows of the matrix found"},
+		{"only comments", "adjacency", "# nothing\n", "no rows of the matrix found"},
+		{"garbage line", "adjacency", "hello world\n", "the matrix has a header but no rows"},
+		{"garbage incidence line", "incidence", "hello\n", "the matrix has a header but no rows"},
+		{"not square", "adjacency", "0 1 0\n1 0 1\n", "must be square, it has 2 rows and 3 columns"},
+		{"ragged rows", "adjacency", "0 1\n1\n", "expected 2 cells in the row, found 1"},
+		{"weight that is not finite", "adjacency", "0 NaN\n0 0\n", "the weight must be a finite number"},
+		{"row and column names differ", "adjacency", "  A B\nA 0 1\nC 0 0\n", "the row 'C' and the column 'B' must be the same vertex"},
+		{"duplicate vertex", "adjacency", "  A A\nA 0 1\nA 0 0\n", "duplicate vertex 'A'"},
+		{"unterminated name", "adjacency", "\"A 0\n", "unterminated quoted name"},
+		{"column without vertices", "incidence", "1 0\n-1 0\n", "the column 2 has no vertices"},
+		{"column with three vertices", "incidence", "1\n1\n1\n", "the column 1 has 3 vertices"},
+		{"different weights at the ends", "incidence", "2\n-3\n", "the same weight at both ends"},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			_, err := Decode(strings.NewReader(test.matrix), test.format)
+			if err == nil {
+				t.Fatalf("no error")
+			}
+			if !strings.Contains(err.Error(), test.want) {
+				t.Errorf("error %q, want %q", err, test.want)
+			}
+		})
+	}
+
+	// The matrix of a non-oriented graph must be symmetric
+	err := decodeAdjacencyMatrix(GraphEmptyConstructor(), strings.NewReader("0 1\n0 0\n"), inputName, MatrixOptions{})
+	if err == nil || !strings.Contains(err.Error(), "must be symmetric") {
+		t.Errorf("error %v, want a symmetric matrix", err)
+	}
+}
@@ -0,0 +1,103 @@
+package graph
+
+import (
+	"fmt"
+	"io"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+// Writing graphs as Mermaid flowcharts (https://mermaid.js.org/syntax/flowchart.html) for Markdown
+// documents: the output goes into a ```mermaid block. The vertices are v0, v1, ... with their values
+// (and notes) as the text, the weights or the labels of Diagram are on the edges.
+// The classes of Diagram become classDef classes, the highlighted edges get a linkStyle
+
+// WriteMermaid saves the graph as a Mermaid flowchart. diagram may be nil
+func WriteMermaid(graph *GraphInfo, path string, diagram *Diagram) error {
+	return WriteFileWith(path, func(w io.Writer) error {
+		return EncodeMermaid(graph, w, diagram)
+	})
+}
+
+// EncodeMermaid writes the graph as a Mermaid flowchart from the left to the right. diagram may be nil
+func EncodeMermaid(graph *GraphInfo, w io.Writer, diagram *Diagram) error {
+	vertices, byNode := diagramVertices(graph, diagram)
+	edges := diagramEdges(graph, diagram, byNode, vertexRadius(vertices))
+
+	lines := []string{"flowchart LR"}
+	for _, v := range vertices {
+		text := v.label
+		if v.note != "" {
+			text += "\n" + v.note
+		}
+		lines = append(lines, fmt.Sprintf("  %s((%s))", v.name, mermaidString(text)))
+	}
+
+	highlighted := make(map[int][]string) // the numbers of the edges of every highlighted set
+	for i, e := range edges {
+		link := "---"
+		if e.edge.IsOriented() {
+			link = "-->"
+		}
+		if e.label != "" {
+			link += "|" + mermaidString(e.label) + "|"
+		}
+		lines = append(lines, fmt.Sprintf("  %s %s %s", e.from.name, link, e.to.name))
+		if e.highlight >= 0 {
+			highlighted[e.highlight] = append(highlighted[e.highlight], strconv.Itoa(i))
+		}
+	}
+
+	for i, class := range diagram.classNames() {
+		var names []string
+		for _, v := range vertices {
+			for _, c := range v.classes {
+				if c == class {
+					names = append(names, v.name)
+				}
+			}
+		}
+		if len(names) == 0 {
+			continue
+		}
+		id := mermaidClass(class)
+		lines = append(lines,
+			fmt.Sprintf("  classDef %s fill:%s,stroke:%s", id, classColor(i), highlightColors[i%len(highlightColors)]),
+			fmt.Sprintf("  class %s %s", strings.Join(names, ","), id))
+	}
+	if diagram != nil {
+		for i := range diagram.Highlights {
+			if numbers := highlighted[i]; len(numbers) > 0 {
+				lines = append(lines, fmt.Sprintf("  linkStyle %s stroke:%s,stroke-width:3px",
+					strings.Join(numbers, ","), highlightColors[i%len(highlightColors)]))
+			}
+		}
+	}
+
+	_, err := io.WriteString(w, strings.Join(lines, "\n")+"\n")
+	return err
+}
+
+// mermaidString returns s as a quoted Mermaid text. Mermaid has no escapes in strings,
+// the quotes and the markup characters are written as entity codes
+func mermaidString(s string) string {
+	s = strings.NewReplacer(
+		"#", "#35;", `"`, "#quot;", "<", "#lt;", ">", "#gt;",
+		"\n", "<br>",
+	).Replace(s)
+	return `"` + s + `"`
+}
+
+// mermaidClassForbidden matches the characters that can not be in the name of a Mermaid class
+var mermaidClassForbidden = regexp.MustCompile(`[^A-Za-z0-9_-]`)
+
+// mermaidClass returns the name of the class with the characters that are not allowed replaced by '_'.
+// "default" is the style of all the vertices in Mermaid, so it is renamed too
+func mermaidClass(class string) string {
+	id := mermaidClassForbidden.ReplaceAllString(class, "_")
+	if id == "" || id == "default" {
+		id += "_"
+	}
+	return id
+}
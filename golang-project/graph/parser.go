@@ -0,0 +1,349 @@
+package graph
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+)
+
+// ParseError is a line of a graph file that can not be read
+type ParseError struct {
+	Path   string
+	Line   int
+	Column int // of the first byte of the wrong text, starting with 1
+	Msg    string
+}
+
+func (e *ParseError) Error() string {
+	return fmt.Sprintf("%s:%d:%d: %s", e.Path, e.Line, e.Column, e.Msg)
+}
+
+// errorAt returns a ParseError at the given column of the current line
+func errorAt(column int, format string, args ...interface{}) *ParseError {
+	if column < 1 {
+		column = 1
+	}
+	return &ParseError{Column: column, Msg: fmt.Sprintf(format, args...)}
+}
+
+// ReadGraphFile reads a graph from the text file at path. The first line that can not be read
+// is returned as a *ParseError: an unknown line, a duplicate vertex or edge, an edge whose arrow
+// contradicts the TYPE line, a malformed weight etc.
+func ReadGraphFile(path string) (*GraphInfo, error) {
+	return ReadFile(path, "text")
+}
+
+// ReadGraphFileLenient reads a graph from the text file at path skipping the lines that can not be read,
+// they are returned as warnings. Returns an error only if the file itself can not be read
+func ReadGraphFileLenient(path string) (*GraphInfo, []*ParseError, error) {
+	graph := GraphEmptyConstructor()
+	warnings, err := readGraphFile(graph, path, true)
+	if err != nil {
+		return nil, nil, err
+	}
+	return graph, warnings, nil
+}
+
+// readGraphFile adds the vertices and edges of the text file at path to graph, see decodeText
+func readGraphFile(graph *GraphInfo, path string, lenient bool) ([]*ParseError, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return nil, fmt.Errorf("Error opening file: %v", err)
+	}
+	defer file.Close()
+
+	return decodeText(graph, file, path, lenient)
+}
+
+// decodeText adds the vertices and edges of the text format read from r to graph.
+// Vertex values and weights are converted by graph.ParseValue and graph.ParseWeight.
+// In the lenient mode the lines that can not be read are skipped and returned,
+// otherwise the first of them is returned as the error. name is the name of the file for the errors
+func decodeText(graph *GraphInfo, r io.Reader, name string, lenient bool) ([]*ParseError, error) {
+	var err error
+	scanner := bufio.NewScanner(r)
+	var lineNumber int
+	var warnings []*ParseError
+	inAttributes := false // the lines of the ATTRIBUTES section are vertex attributes
+
+	for scanner.Scan() {
+		rawLine := scanner.Text()
+		line := strings.TrimSpace(rawLine)
+		lineNumber++
+
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue // Skip empty lines and comments
+		}
+
+		if strings.HasPrefix(line, "TYPE:") {
+			// Parse graph type
+			err = parseGraphType(graph, line)
+		} else if strings.HasPrefix(line, "VERTICES:") {
+			// Parse vertices
+			err = parseVertices(graph, line)
+		} else if line == "ATTRIBUTES:" {
+			// Parse vertex attributes section start
+			inAttributes = true
+			continue
+		} else if line == "EDGES:" {
+			// Parse edges section start
+			inAttributes = false
+			continue // Next lines will be edges
+		} else if inAttributes {
+			err = parseVertexAttributes(graph, line)
+		} else {
+			err = parseEdgeLine(graph, line)
+		}
+
+		if err == nil {
+			continue
+		}
+
+		// Columns are counted in the line as it is in the file
+		var parseErr *ParseError
+		if !errors.As(err, &parseErr) {
+			parseErr = errorAt(1, "%v", err)
+		}
+		parseErr.Path = name
+		parseErr.Line = lineNumber
+		parseErr.Column += strings.Index(rawLine, line)
+
+		if !lenient {
+			return nil, parseErr
+		}
+		warnings = append(warnings, parseErr)
+	}
+
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("Error reading file: %v", err)
+	}
+	return warnings, nil
+}
+
+// parseGraphType reads "TYPE: DIRECTED WEIGHTED MULTI". Known words are applied
+// even if there are unknown ones, the first unknown word is returned as the error
+func parseGraphType(graph *GraphInfo, line string) error {
+	typeStr := strings.ToUpper(line)
+	words := strings.Fields(strings.TrimPrefix(typeStr, "TYPE:")) // Split into individual words
+
+	var err error
+	for _, word := range words {
+		switch word {
+		case "DIRECTED":
+			graph.isOriented = true
+		case "UNDIRECTED":
+			graph.isOriented = false
+		case "MIXED":
+			graph.isOriented = true
+			graph.isMixed = true
+		case "WEIGHTED":
+			graph.isWeighted = true
+		case "UNWEIGHTED":
+			graph.isWeighted = false
+		case "MULTI", "MULTIGRAPH":
+			graph.isMultigraph = true
+		default:
+			if err == nil {
+				err = errorAt(strings.Index(typeStr, word)+1, "unknown graph type '%s'", word)
+			}
+		}
+	}
+	return err
+}
+
+// parseVertices reads "VERTICES: A,B,C". All the vertices that can be read are added,
+// the first one that can not (e.g. a duplicate) is returned as the error
+func parseVertices(graph *GraphInfo, line string) error {
+	lex := &lexer{line: line, pos: len("VERTICES:")}
+
+	var err error
+	for {
+		tok, lexErr := lex.next()
+		if lexErr != nil {
+			return lexErr
+		}
+		if tok.kind == tokenEnd {
+			return err
+		}
+		if tok.kind == tokenComma {
+			continue // an empty item
+		}
+		if tok.kind != tokenIdentifier {
+			return errorAt(tok.column, "expected a vertex, got '%s'", tok.text)
+		}
+
+		value, valueErr := graph.ParseValue(tok.text)
+		if valueErr == nil && FindNodeByValue(graph, value) != nil {
+			valueErr = fmt.Errorf("duplicate vertex '%s'", tok.text)
+		}
+		if valueErr == nil {
+			valueErr = AddVertex(graph, NodeConstructor(value))
+		}
+		if valueErr != nil && err == nil {
+			err = errorAt(tok.column, "%v", valueErr)
+		}
+
+		separator, lexErr := lex.next()
+		if lexErr != nil {
+			return lexErr
+		}
+		if separator.kind == tokenEnd {
+			return err
+		}
+		if separator.kind != tokenComma {
+			return errorAt(separator.column, "expected ',' between vertices, got '%s'", separator.text)
+		}
+	}
+}
+
+// parseEdgeLine reads an edge in one of the supported formats:
+// "A->B: 5", "A-B: -3", "A B 5" (the edge is oriented if the graph is).
+// Edges may end with attributes: "A->B: 5 [color=red]"
+func parseEdgeLine(graph *GraphInfo, line string) error {
+	lex := &lexer{line: line}
+
+	from, err := lex.next()
+	if err != nil {
+		return err
+	}
+	if from.kind != tokenIdentifier {
+		return errorAt(from.column, "expected a vertex, got '%s'", from.text)
+	}
+
+	operator, err := lex.next()
+	if err != nil {
+		return err
+	}
+
+	var to, weight token
+	var oriented bool
+	switch operator.kind {
+	case tokenArrow, tokenDash:
+		oriented = operator.kind == tokenArrow
+		if to, err = lex.next(); err != nil {
+			return err
+		}
+		if to.kind != tokenIdentifier {
+			return errorAt(to.column, "expected a vertex after '%s'", operator.text)
+		}
+		colon, err := lex.peek()
+		if err != nil {
+			return err
+		}
+		if colon.kind == tokenColon {
+			lex.next()
+			if weight = lex.weight(); weight.kind == tokenEnd {
+				return errorAt(colon.column, "missing weight after ':'")
+			}
+		}
+	case tokenIdentifier:
+		// Simple edge list format (fallback)
+		to = operator
+		oriented = graph.isOriented
+		weight = lex.weight()
+	default:
+		return errorAt(operator.column, "expected '->', '-' or a vertex after '%s'", from.text)
+	}
+
+	attributes, err := parseAttributeBlock(lex)
+	if err != nil {
+		return err
+	}
+	return addParsedEdge(graph, from, to, weight, oriented, attributes)
+}
+
+// parseVertexAttributes reads a line of the ATTRIBUTES section: "A [color=red, x=1.5]"
+func parseVertexAttributes(graph *GraphInfo, line string) error {
+	lex := &lexer{line: line}
+	vertex, err := lex.next()
+	if err != nil {
+		return err
+	}
+	if vertex.kind != tokenIdentifier {
+		return errorAt(vertex.column, "expected a vertex, got '%s'", vertex.text)
+	}
+	attributes, err := parseAttributeBlock(lex)
+	if err != nil {
+		return err
+	}
+
+	value, err := graph.ParseValue(vertex.text)
+	if err != nil {
+		return errorAt(vertex.column, "%v", err)
+	}
+	node := FindNodeByValue(graph, value)
+	if node == nil {
+		return errorAt(vertex.column, "unknown vertex '%s'", vertex.text)
+	}
+	for _, key := range attributes.Keys() {
+		node.Attributes.Set(key, attributes[key])
+	}
+	return nil
+}
+
+// parseAttributeBlock reads the optional attribute block that ends the line
+func parseAttributeBlock(lex *lexer) (Attributes, error) {
+	tok, err := lex.next()
+	if err != nil {
+		return nil, err
+	}
+	switch tok.kind {
+	case tokenEnd:
+		return nil, nil
+	case tokenBlock:
+		attributes, err := parseAttributes(tok.text)
+		if err != nil {
+			return nil, errorAt(tok.column, "%v", err)
+		}
+		return attributes, nil
+	}
+	return nil, errorAt(tok.column, "unexpected '%s'", tok.text)
+}
+
+// addParsedEdge converts the text of the edge's ends and weight and adds the edge with the given attributes to graph.
+// A tokenEnd weight means the edge has no weight, only a WEIGHTED graph can have weights.
+// Only a mixed graph can have edges whose direction differs from the graph's type
+func addParsedEdge(graph *GraphInfo, fromTok, toTok, weightTok token, oriented bool, attributes Attributes) error {
+	if !graph.isMixed && oriented != graph.isOriented {
+		if oriented {
+			return errorAt(fromTok.column, "oriented edge '%s->%s' in an UNDIRECTED graph, use TYPE: MIXED to combine both kinds of edges", fromTok.text, toTok.text)
+		}
+		return errorAt(fromTok.column, "non-oriented edge '%s-%s' in a DIRECTED graph, use TYPE: MIXED to combine both kinds of edges", fromTok.text, toTok.text)
+	}
+
+	from, err := graph.ParseValue(fromTok.text)
+	if err != nil {
+		return errorAt(fromTok.column, "%v", err)
+	}
+	to, err := graph.ParseValue(toTok.text)
+	if err != nil {
+		return errorAt(toTok.column, "%v", err)
+	}
+
+	var weight float64
+	var exact interface{}
+	if weightTok.kind == tokenWeight {
+		if !graph.isWeighted {
+			return errorAt(weightTok.column, "weight '%s' in an UNWEIGHTED graph, use TYPE: WEIGHTED", weightTok.text)
+		}
+		weight, exact, err = graph.ParseWeight(weightTok.text)
+		if err != nil {
+			return errorAt(weightTok.column, "%v", err)
+		}
+	}
+
+	var edge *Edge
+	if oriented {
+		edge, err = addEdgeBetweenNodes(graph, from, to, weight, exact)
+	} else {
+		edge, err = addNonOrientedEdgeBetweenNodes(graph, from, to, weight, exact)
+	}
+	if err != nil {
+		return errorAt(fromTok.column, "duplicate edge between '%s' and '%s'", fromTok.text, toTok.text)
+	}
+	edge.Attributes = attributes
+	return nil
+}
@@ -0,0 +1,77 @@
+package graph
+
+import (
+	"fmt"
+	"io"
+	"strings"
+)
+
+// Writing graphs as PlantUML diagrams (https://plantuml.com). The vertices are agents v0, v1, ...
+// with their values (and notes) as the text, the weights or the labels of Diagram are on the edges.
+// The classes of Diagram become stereotypes with their colors, the highlighted edges are bold in
+// the colors of their sets
+
+// WritePlantUML saves the graph as a PlantUML diagram. diagram may be nil
+func WritePlantUML(graph *GraphInfo, path string, diagram *Diagram) error {
+	return WriteFileWith(path, func(w io.Writer) error {
+		return EncodePlantUML(graph, w, diagram)
+	})
+}
+
+// EncodePlantUML writes the graph as a PlantUML diagram from the left to the right. diagram may be nil
+func EncodePlantUML(graph *GraphInfo, w io.Writer, diagram *Diagram) error {
+	vertices, byNode := diagramVertices(graph, diagram)
+	edges := diagramEdges(graph, diagram, byNode, vertexRadius(vertices))
+
+	lines := []string{"@startuml", "left to right direction"}
+	if classes := diagram.classNames(); len(classes) > 0 {
+		lines = append(lines, "skinparam agent {")
+		for i, class := range classes {
+			lines = append(lines, fmt.Sprintf("  BackgroundColor<<%s>> %s", plantUMLStereotype(class), classColor(i)))
+		}
+		lines = append(lines, "}")
+	}
+
+	for _, v := range vertices {
+		text := v.label
+		if v.note != "" {
+			text += "\n" + v.note
+		}
+		stereotypes := ""
+		for _, class := range v.classes {
+			stereotypes += " <<" + plantUMLStereotype(class) + ">>"
+		}
+		lines = append(lines, fmt.Sprintf(`agent "%s" as %s%s`, plantUMLText(text), v.name, stereotypes))
+	}
+
+	for _, e := range edges {
+		style := ""
+		if color := e.color(); color != "" {
+			style = "[#" + color + ",bold]"
+		}
+		arrow := "-" + style + "-"
+		if e.edge.IsOriented() {
+			arrow += ">"
+		}
+		line := fmt.Sprintf("%s %s %s", e.from.name, arrow, e.to.name)
+		if e.label != "" {
+			line += " : " + plantUMLText(e.label)
+		}
+		lines = append(lines, line)
+	}
+	lines = append(lines, "@enduml")
+
+	_, err := io.WriteString(w, strings.Join(lines, "\n")+"\n")
+	return err
+}
+
+// plantUMLText escapes s for a quoted PlantUML text: a quote is written as its code point,
+// a new line as \n
+func plantUMLText(s string) string {
+	return strings.NewReplacer(`\`, `\\`, `"`, "<U+0022>", "\n", `\n`, "\r", "").Replace(s)
+}
+
+// plantUMLStereotype returns the class as a stereotype, without the characters that end it
+func plantUMLStereotype(class string) string {
+	return strings.NewReplacer("<", "_", ">", "_", "\n", " ").Replace(class)
+}
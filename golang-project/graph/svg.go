@@ -0,0 +1,163 @@
+package graph
+
+import (
+	"fmt"
+	"io"
+	"math"
+	"strings"
+)
+
+// Rendering graphs to standalone SVG images, without Graphviz. The drawing is the same as of EncodeCeTZ:
+// the vertices at the positions of the layout (AutoLayout by default) with their notes, arrows on
+// the oriented edges, the weights or the labels of Diagram on the edges and the highlighted edges
+// thick in the colors of their sets
+
+// The sizes of an SVG image
+const (
+	svgScale    = 40  // pixels in a centimeter of the layout
+	svgPadding  = 0.4 // the empty border in centimeters
+	svgFontSize = 14
+)
+
+// WriteSVG saves the graph as an SVG image. diagram may be nil
+func WriteSVG(graph *GraphInfo, path string, diagram *Diagram) error {
+	return WriteFileWith(path, func(w io.Writer) error {
+		return EncodeSVG(graph, w, diagram)
+	})
+}
+
+// EncodeSVG writes the graph as an SVG image. diagram may be nil
+func EncodeSVG(graph *GraphInfo, w io.Writer, diagram *Diagram) error {
+	vertices, byNode := diagramVertices(graph, diagram)
+	radius := vertexRadius(vertices)
+	edges := diagramEdges(graph, diagram, byNode, radius)
+
+	// The bounding box of everything that is drawn, in centimeters
+	minX, minY := math.Inf(1), math.Inf(1)
+	maxX, maxY := math.Inf(-1), math.Inf(-1)
+	extend := func(p Point, dx float64, dy float64) {
+		minX, maxX = math.Min(minX, p.X-dx), math.Max(maxX, p.X+dx)
+		minY, maxY = math.Min(minY, p.Y-dy), math.Max(maxY, p.Y+dy)
+	}
+	textWidth := func(s string) float64 {
+		return float64(len([]rune(s))) * svgFontSize * 0.6 / svgScale
+	}
+	textHeight := float64(svgFontSize) / svgScale
+	for _, v := range vertices {
+		extend(v.position, radius, radius)
+		if v.note != "" {
+			corner := Point{X: v.position.X + radius*0.7, Y: v.position.Y + radius*0.7}
+			extend(Point{X: corner.X + textWidth(v.note), Y: corner.Y + textHeight}, 0, 0)
+		}
+	}
+	for _, e := range edges {
+		if e.isLoop() {
+			_, c1, c2, _ := e.loopCurve(radius)
+			extend(c1, 0, 0)
+			extend(c2, 0, 0)
+		}
+		if e.label != "" {
+			extend(e.labelPosition(radius), textWidth(e.label)/2, textHeight/2)
+		}
+	}
+	if len(vertices) == 0 {
+		minX, minY, maxX, maxY = 0, 0, 0, 0
+	}
+	minX, minY, maxX, maxY = minX-svgPadding, minY-svgPadding, maxX+svgPadding, maxY+svgPadding
+
+	// The y axis of SVG goes down
+	x := func(p Point) string { return formatLength((p.X - minX) * svgScale) }
+	y := func(p Point) string { return formatLength((maxY - p.Y) * svgScale) }
+	xy := func(p Point) string { return x(p) + "," + y(p) }
+
+	width, height := formatLength((maxX-minX)*svgScale), formatLength((maxY-minY)*svgScale)
+	lines := []string{
+		fmt.Sprintf(`<svg xmlns="http://www.w3.org/2000/svg" width="%s" height="%s" viewBox="0 0 %s %s" font-family="sans-serif" font-size="%d">`,
+			width, height, width, height, svgFontSize),
+		fmt.Sprintf("  <!-- %d vertices, %d edges -->", len(vertices), len(edges)),
+	}
+
+	// An arrowhead for every color of the oriented edges
+	var markers []string
+	marked := make(map[string]bool)
+	for _, e := range edges {
+		color := edgeColor(e)
+		if e.edge.IsOriented() && !marked[color] {
+			marked[color] = true
+			markers = append(markers, fmt.Sprintf(`    <marker id="arrow-%s" viewBox="0 0 10 10" refX="10" refY="5" markerUnits="userSpaceOnUse" markerWidth="10" markerHeight="10" orient="auto-start-reverse">`+
+				`<path d="M0,0 L10,5 L0,10 z" fill="%s"/></marker>`, color, color))
+		}
+	}
+	if len(markers) > 0 {
+		lines = append(lines, "  <defs>")
+		lines = append(lines, markers...)
+		lines = append(lines, "  </defs>")
+	}
+
+	lines = append(lines, `  <g fill="none" stroke="black" stroke-width="1.5">`)
+	for _, e := range edges {
+		var d string
+		if e.isLoop() {
+			s, c1, c2, t := e.loopCurve(radius)
+			d = fmt.Sprintf("M%s C%s %s %s", xy(s), xy(c1), xy(c2), xy(t))
+		} else if s, c, t := e.curve(radius); e.bend != 0 {
+			d = fmt.Sprintf("M%s Q%s %s", xy(s), xy(c), xy(t))
+		} else {
+			d = fmt.Sprintf("M%s L%s", xy(s), xy(t))
+		}
+
+		attributes := ""
+		if color := e.color(); color != "" {
+			attributes += fmt.Sprintf(` stroke="%s" stroke-width="3"`, color)
+		}
+		if e.edge.IsOriented() {
+			attributes += fmt.Sprintf(` marker-end="url(#arrow-%s)"`, edgeColor(e))
+		}
+		lines = append(lines, fmt.Sprintf(`    <path d="%s"%s/>`, d, attributes))
+	}
+	lines = append(lines, "  </g>")
+
+	// The labels have a white outline, so that they can be read over the edges
+	lines = append(lines, `  <g text-anchor="middle" dominant-baseline="central" stroke="white" stroke-width="4" paint-order="stroke">`)
+	for _, e := range edges {
+		if e.label != "" {
+			p := e.labelPosition(radius)
+			lines = append(lines, fmt.Sprintf(`    <text x="%s" y="%s">%s</text>`, x(p), y(p), svgEscape(e.label)))
+		}
+	}
+	lines = append(lines, "  </g>")
+
+	lines = append(lines, `  <g text-anchor="middle" dominant-baseline="central">`)
+	for _, v := range vertices {
+		fill := "white"
+		if v.fill != "" {
+			fill = v.fill
+		}
+		lines = append(lines,
+			fmt.Sprintf(`    <circle cx="%s" cy="%s" r="%s" fill="%s" stroke="black" stroke-width="1.5"/>`,
+				x(v.position), y(v.position), formatLength(radius*svgScale), fill),
+			fmt.Sprintf(`    <text x="%s" y="%s">%s</text>`, x(v.position), y(v.position), svgEscape(v.label)))
+		if v.note != "" {
+			corner := Point{X: v.position.X + radius*0.7, Y: v.position.Y + radius*0.7}
+			lines = append(lines, fmt.Sprintf(`    <text x="%s" y="%s" text-anchor="start" dominant-baseline="text-after-edge" font-size="%d" fill="dimgray">%s</text>`,
+				x(corner), y(corner), svgFontSize-2, svgEscape(v.note)))
+		}
+	}
+	lines = append(lines, "  </g>", "</svg>")
+
+	_, err := io.WriteString(w, strings.Join(lines, "\n")+"\n")
+	return err
+}
+
+// edgeColor returns the color the edge is drawn in
+func edgeColor(e *diagramEdge) string {
+	if color := e.color(); color != "" {
+		return color
+	}
+	return "black"
+}
+
+// svgEscape escapes the special characters of XML in s
+func svgEscape(s string) string {
+	return strings.NewReplacer("&", "&amp;", "<", "&lt;", ">", "&gt;", `"`, "&quot;").Replace(s)
+}
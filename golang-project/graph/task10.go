@@ -1,4 +1,4 @@
-package main
+package graph
 
 import (
 	"math"
@@ -6,17 +6,22 @@ import (
 
 // BellmanFordResult представляет результат алгоритма Беллмана-Форда
 type BellmanFordResult struct {
+	Start              *Node             // Стартовая вершина
 	Distances          map[*Node]float64 // Кратчайшие расстояния от стартовой вершины
 	Predecessors       map[*Node]*Node   // Предшественники для восстановления путей
+	PredecessorEdges   map[*Node]*Edge   // Рёбра из предшественников, по которым проходят пути
 	HasNegativeCycle   bool              // Флаг наличия достижимого цикла отрицательного веса
 	NegativeCycleNodes []*Node           // Вершины, достижимые из цикла отрицательного веса
 }
 
-// bellmanFord реализует алгоритм Беллмана-Форда для нахождения кратчайших путей из одной вершины
-func bellmanFord(g *GraphInfo, start *Node) *BellmanFordResult {
+// BellmanFord реализует алгоритм Беллмана-Форда для нахождения кратчайших путей из одной вершины.
+// Параллельные рёбра мультиграфа релаксируются по отдельности, т.е. путь проходит по самому лёгкому из них
+func BellmanFord(g *GraphInfo, start *Node) *BellmanFordResult {
 	result := &BellmanFordResult{
+		Start:              start,
 		Distances:          make(map[*Node]float64),
 		Predecessors:       make(map[*Node]*Node),
+		PredecessorEdges:   make(map[*Node]*Edge),
 		HasNegativeCycle:   false,
 		NegativeCycleNodes: make([]*Node, 0),
 	}
@@ -36,26 +41,29 @@ func bellmanFord(g *GraphInfo, start *Node) *BellmanFordResult {
 	}
 
 	// Собираем все рёбра графа
-	edges := getAllEdges(g)
+	edges := GetAllEdges(g)
 
 	// Фаза релаксации: |V| - 1 итераций
 	for i := 0; i < len(g.nodes)-1; i++ {
 		changed := false
 		for _, edge := range edges {
-			u := edge.List[0]
-			v := edge.List[1]
 			weight := edge.Weight
 
 			if !g.isWeighted {
 				weight = 1
 			}
 
-			if result.Distances[u] < math.Inf(1) {
-				newDist := result.Distances[u] + weight
-				if newDist < result.Distances[v] {
-					result.Distances[v] = newDist
-					result.Predecessors[v] = u
-					changed = true
+			// Неориентированное ребро релаксируется в обоих направлениях
+			for _, ends := range edgeDirections(edge) {
+				u, v := ends[0], ends[1]
+				if result.Distances[u] < math.Inf(1) {
+					newDist := result.Distances[u] + weight
+					if newDist < result.Distances[v] {
+						result.Distances[v] = newDist
+						result.Predecessors[v] = u
+						result.PredecessorEdges[v] = edge
+						changed = true
+					}
 				}
 			}
 		}
@@ -68,36 +76,53 @@ func bellmanFord(g *GraphInfo, start *Node) *BellmanFordResult {
 	// Проверка на циклы отрицательного веса
 	result.HasNegativeCycle = false
 	for _, edge := range edges {
-		u := edge.List[0]
-		v := edge.List[1]
 		weight := edge.Weight
 
 		if !g.isWeighted {
 			weight = 1
 		}
 
-		if result.Distances[u] < math.Inf(1) {
-			if result.Distances[u]+weight < result.Distances[v] {
+		for _, ends := range edgeDirections(edge) {
+			u, v := ends[0], ends[1]
+			if result.Distances[u] < math.Inf(1) && result.Distances[u]+weight < result.Distances[v] {
 				result.HasNegativeCycle = true
 				// Помечаем вершины, достижимые из цикла отрицательного веса
 				result.markNodesReachableFromNegativeCycle(g, v)
 				break
 			}
 		}
+		if result.HasNegativeCycle {
+			break
+		}
 	}
 
 	return result
 }
 
-// getAllEdges возвращает все рёбра графа
-func getAllEdges(g *GraphInfo) []*Edge {
-	edges := make([]*Edge, 0)
-	for _, edgeList := range g.connectionsList {
-		edges = append(edges, edgeList...)
+// GetAllEdges возвращает все рёбра графа, каждое по одному разу (в порядке вершин)
+func GetAllEdges(g *GraphInfo) []*Edge {
+	edges := make([]*Edge, 0, len(g.edgesById))
+	seen := make(map[*Edge]bool)
+	for _, node := range g.nodes {
+		for _, edge := range g.connectionsList[node] {
+			if !seen[edge] {
+				seen[edge] = true
+				edges = append(edges, edge)
+			}
+		}
 	}
 	return edges
 }
 
+// edgeDirections возвращает направления, в которых можно пройти по ребру:
+// одно для ориентированного ребра и два для неориентированного
+func edgeDirections(edge *Edge) [][2]*Node {
+	if edge.undirected && edge.List[0] != edge.List[1] {
+		return [][2]*Node{{edge.List[0], edge.List[1]}, {edge.List[1], edge.List[0]}}
+	}
+	return [][2]*Node{{edge.List[0], edge.List[1]}}
+}
+
 // markNodesReachableFromNegativeCycle помечает все вершины, достижимые из цикла отрицательного веса
 func (r *BellmanFordResult) markNodesReachableFromNegativeCycle(g *GraphInfo, start *Node) {
 	visited := make(map[*Node]bool)
@@ -113,7 +138,7 @@ func (r *BellmanFordResult) markNodesReachableFromNegativeCycle(g *GraphInfo, st
 
 		// Добавляем всех соседей
 		for _, edge := range g.connectionsList[current] {
-			neighbor := edge.List[1]
+			neighbor := edge.Other(current)
 			if !visited[neighbor] {
 				visited[neighbor] = true
 				queue = append(queue, neighbor)
@@ -122,8 +147,8 @@ func (r *BellmanFordResult) markNodesReachableFromNegativeCycle(g *GraphInfo, st
 	}
 }
 
-// reconstructPath восстанавливает путь от стартовой вершины до целевой
-func (r *BellmanFordResult) reconstructPath(target *Node) []*Node {
+// ReconstructPath восстанавливает путь от стартовой вершины до целевой
+func (r *BellmanFordResult) ReconstructPath(target *Node) []*Node {
 	if math.IsInf(r.Distances[target], -1) {
 		return nil // Путь через цикл отрицательного веса
 	}
@@ -1,8 +1,7 @@
-package main
+package graph
 
 import (
 	"container/list"
-	"fmt"
 	"math"
 )
 
@@ -11,6 +10,7 @@ type FlowEdge struct {
 	From     *Node
 	To       *Node
 	Capacity float64
+	Edges    []*Edge // рёбра графа, из которых создано ребро сети
 }
 
 // FlowNetwork представляет потоковую сеть
@@ -29,8 +29,10 @@ type MaxFlowResult struct {
 	Sink         *Node
 }
 
-// createFlowNetwork создаёт потоковую сеть из обычного графа
-func createFlowNetwork(g *GraphInfo) *FlowNetwork {
+// CreateFlowNetwork создаёт потоковую сеть из обычного графа.
+// Параллельные рёбра мультиграфа объединяются в одно ребро сети с суммарной пропускной способностью.
+// Рёбра с отрицательной пропускной способностью пропускаются и возвращаются вторым значением
+func CreateFlowNetwork(g *GraphInfo) (*FlowNetwork, []*Edge) {
 	network := &FlowNetwork{
 		Nodes: make([]*Node, len(g.nodes)),
 		Edges: make([]*FlowEdge, 0),
@@ -40,9 +42,31 @@ func createFlowNetwork(g *GraphInfo) *FlowNetwork {
 	// Копируем вершины
 	copy(network.Nodes, g.nodes)
 
+	var skipped []*Edge
+	skippedSet := make(map[*Edge]bool)
+
+	// Уже созданные рёбра сети по паре (откуда, куда) - для объединения параллельных рёбер
+	existing := make(map[[2]*Node]*FlowEdge)
+	addFlowEdge := func(from *Node, to *Node, capacity float64, edge *Edge) {
+		if flowEdge, ok := existing[[2]*Node{from, to}]; ok {
+			flowEdge.Capacity += capacity
+			flowEdge.Edges = append(flowEdge.Edges, edge)
+			return
+		}
+		flowEdge := &FlowEdge{
+			From:     from,
+			To:       to,
+			Capacity: capacity,
+			Edges:    []*Edge{edge},
+		}
+		existing[[2]*Node{from, to}] = flowEdge
+		network.Edges = append(network.Edges, flowEdge)
+		network.Adj[from] = append(network.Adj[from], flowEdge)
+	}
+
 	// Создаём рёбра потоковой сети
-	for fromNode, edges := range g.connectionsList {
-		for _, edge := range edges {
+	for _, fromNode := range g.nodes {
+		for _, edge := range g.connectionsList[fromNode] {
 			capacity := edge.Weight
 
 			// Если граф невзвешенный, используем capacity = 1
@@ -55,40 +79,27 @@ func createFlowNetwork(g *GraphInfo) *FlowNetwork {
 				capacity = 1
 			}
 
-			// Проверяем на отрицательную вместимость
+			// Пропускаем рёбра с отрицательной пропускной способностью,
+			// неориентированное ребро встречается дважды, но возвращается один раз
 			if capacity < 0 {
-				fmt.Printf("\033[31mWarning\033[0m: edge from %v to %v has negative capacity: %.2f\n",
-					fromNode.Value, edge.List[1].Value, capacity)
-				continue // Пропускаем рёбра с отрицательной пропускной способностью
-			}
-
-			flowEdge := &FlowEdge{
-				From:     fromNode,
-				To:       edge.List[1],
-				Capacity: capacity,
-			}
-
-			network.Edges = append(network.Edges, flowEdge)
-			network.Adj[fromNode] = append(network.Adj[fromNode], flowEdge)
-
-			// Для неориентированного графа добавляем обратное ребро с той же пропускной способностью
-			if !g.isOriented {
-				reverseEdge := &FlowEdge{
-					From:     edge.List[1],
-					To:       fromNode,
-					Capacity: capacity,
+				if !skippedSet[edge] {
+					skippedSet[edge] = true
+					skipped = append(skipped, edge)
 				}
-				network.Edges = append(network.Edges, reverseEdge)
-				network.Adj[edge.List[1]] = append(network.Adj[edge.List[1]], reverseEdge)
+				continue
 			}
+
+			// Неориентированное ребро есть в списках обоих концов,
+			// поэтому из него создаются рёбра сети в обоих направлениях
+			addFlowEdge(fromNode, edge.Other(fromNode), capacity, edge)
 		}
 	}
 
-	return network
+	return network, skipped
 }
 
-// edmondsKarp реализует алгоритм Эдмондса-Карпа для поиска максимального потока
-func edmondsKarp(network *FlowNetwork, source *Node, sink *Node) *MaxFlowResult {
+// EdmondsKarp реализует алгоритм Эдмондса-Карпа для поиска максимального потока
+func EdmondsKarp(network *FlowNetwork, source *Node, sink *Node) *MaxFlowResult {
 	result := &MaxFlowResult{
 		MaxFlowValue: 0,
 		Flow:         make(map[*FlowEdge]float64),
@@ -1,7 +1,7 @@
-package main
+package graph
 
-// knots returns all vertices that have self-loops (edges from a vertex to itself)
-func knots(graph *GraphInfo) []*Node {
+// Knots returns all vertices that have self-loops (edges from a vertex to itself)
+func Knots(graph *GraphInfo) []*Node {
 	var knots []*Node
 
 	// Only makes sense for directed graphs
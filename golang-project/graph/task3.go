@@ -0,0 +1,16 @@
+package graph
+
+// VerticesWithLesserInDegree returns all vertices whose in-degree (half-degree of entrance)
+// is less than that of nodeGiven
+func VerticesWithLesserInDegree(graph *GraphInfo, nodeGiven *Node) []*Node {
+	var nodes []*Node
+
+	var degreeGiven = graph.InDegree(nodeGiven)
+
+	for _, node := range graph.nodes {
+		if graph.InDegree(node) < degreeGiven {
+			nodes = append(nodes, node)
+		}
+	}
+	return nodes
+}
@@ -1,6 +1,7 @@
-package main
+package graph
 
-func task4Func(graph *GraphInfo) []*Node {
+// IsolatedVertices returns all vertices that are not an endpoint of any edge
+func IsolatedVertices(graph *GraphInfo) []*Node {
 	var isIsolatedList = make(map[*Node]bool, len(graph.connectionsList))
 	for _, node := range graph.nodes {
 		isIsolatedList[node] = false
@@ -1,21 +1,21 @@
-package main
+package graph
 
-// task5Func calculates the cyclomatic number (cycle rank) of a graph
+// CyclomaticNumber calculates the cyclomatic number (cycle rank) of a graph
 // Formula: mu = e - v + p
 // where: e = number of edges, v = number of vertices, p = number of connected components
-func task5Func(g *GraphInfo) int {
+func CyclomaticNumber(g *GraphInfo) int {
 	if len(g.nodes) == 0 {
 		return 0
 	}
 
 	// Count edges
-	e := countEdges(g)
+	e := CountEdges(g)
 
 	// Count vertices
 	v := len(g.nodes)
 
 	// Count connected components
-	p := countConnectedComponents(g)
+	p := CountConnectedComponents(g)
 
 	// Calculate cyclomatic number
 	cyclomaticNumber := e - v + p
@@ -28,25 +28,14 @@ func task5Func(g *GraphInfo) int {
 	return cyclomaticNumber
 }
 
-// countEdges returns the total number of edges in the graph
-func countEdges(g *GraphInfo) int {
-	edgeCount := 0
-	if g.connectionsList != nil {
-		for _, edges := range g.connectionsList {
-			edgeCount += len(edges)
-		}
-	}
-
-	// For undirected graphs, each edge is stored twice, so divide by 2
-	if !g.isOriented {
-		edgeCount = edgeCount / 2
-	}
-
-	return edgeCount
+// CountEdges returns the total number of edges in the graph
+func CountEdges(g *GraphInfo) int {
+	// Every edge, oriented or not, is stored once
+	return len(g.edgesById)
 }
 
-// countConnectedComponents returns the number of connected components in the graph
-func countConnectedComponents(g *GraphInfo) int {
+// CountConnectedComponents returns the number of connected components in the graph
+func CountConnectedComponents(g *GraphInfo) int {
 	if len(g.nodes) == 0 {
 		return 0
 	}
@@ -82,7 +71,7 @@ func bfs(g *GraphInfo, start *Node, visited map[*Node]bool) {
 
 		// Visit all neighbors
 		for _, edge := range g.connectionsList[current] {
-			neighbor := edge.List[1]
+			neighbor := edge.Other(current)
 			if !visited[neighbor] {
 				visited[neighbor] = true
 				queue = append(queue, neighbor)
@@ -119,16 +108,12 @@ func getNeighborsIgnoringDirection(g *GraphInfo, node *Node) []*Node {
 
 	// Outgoing edges
 	for _, edge := range g.connectionsList[node] {
-		neighbors[edge.List[1]] = true
+		neighbors[edge.Other(node)] = true
 	}
 
-	// Incoming edges (need to search all edges)
-	for fromNode, edges := range g.connectionsList {
-		for _, edge := range edges {
-			if edge.List[1] == node {
-				neighbors[fromNode] = true
-			}
-		}
+	// Incoming edges
+	for _, edge := range g.incomingList[node] {
+		neighbors[edge.Other(node)] = true
 	}
 
 	// Convert map to slice
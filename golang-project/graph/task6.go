@@ -1,17 +1,17 @@
-package main
+package graph
 
-// findCommonVertexWithEqualPathLength finds a vertex reachable from both u and v with paths of equal length
+// FindCommonVertexWithEqualPathLength finds a vertex reachable from both u and v with paths of equal length
 // Returns the target vertex and the common path length, or nil if no such vertex exists
-func findCommonVertexWithEqualPathLength(g *GraphInfo, u *Node, v *Node) (*Node, int) {
+func FindCommonVertexWithEqualPathLength(g *GraphInfo, u *Node, v *Node) (*Node, int) {
 	if g == nil || u == nil || v == nil {
 		return nil, -1
 	}
 
 	// Get all vertices reachable from u with their distances
-	distancesFromU := bfsWithDistances(g, u)
+	distancesFromU := BfsWithDistances(g, u)
 
 	// Get all vertices reachable from v with their distances
-	distancesFromV := bfsWithDistances(g, v)
+	distancesFromV := BfsWithDistances(g, v)
 
 	// Find common vertices with equal distances
 	for vertex, distU := range distancesFromU {
@@ -27,8 +27,8 @@ func findCommonVertexWithEqualPathLength(g *GraphInfo, u *Node, v *Node) (*Node,
 	return nil, -1
 }
 
-// bfsWithDistances performs BFS and returns a map of vertices to their shortest distances
-func bfsWithDistances(g *GraphInfo, start *Node) map[*Node]int {
+// BfsWithDistances performs BFS and returns a map of vertices to their shortest distances
+func BfsWithDistances(g *GraphInfo, start *Node) map[*Node]int {
 	distances := make(map[*Node]int)
 	visited := make(map[*Node]bool)
 	queue := []*Node{start}
@@ -41,9 +41,9 @@ func bfsWithDistances(g *GraphInfo, start *Node) map[*Node]int {
 		queue = queue[1:]
 		currentDistance := distances[current]
 
-		// Visit all neighbors
+		// Visit all neighbors. An oriented edge of a mixed graph is only in the list of its 'from' vertex
 		for _, edge := range g.connectionsList[current] {
-			neighbor := edge.List[1]
+			neighbor := edge.Other(current)
 			if !visited[neighbor] {
 				visited[neighbor] = true
 				distances[neighbor] = currentDistance + 1
@@ -55,8 +55,9 @@ func bfsWithDistances(g *GraphInfo, start *Node) map[*Node]int {
 	return distances
 }
 
-// Alternative version that considers all possible path lengths (not just shortest)
-func findCommonVertexWithEqualPathLengthAllPaths(g *GraphInfo, u *Node, v *Node) (*Node, int) {
+// FindCommonVertexWithEqualPathLengthAllPaths is an alternative version of FindCommonVertexWithEqualPathLength
+// that considers all possible path lengths (not just shortest)
+func FindCommonVertexWithEqualPathLengthAllPaths(g *GraphInfo, u *Node, v *Node) (*Node, int) {
 	if g == nil || u == nil || v == nil {
 		return nil, -1
 	}
@@ -98,7 +99,7 @@ func findAllPathLengths(g *GraphInfo, start *Node) map[*Node]map[int]bool {
 
 		// Continue to neighbors
 		for _, edge := range g.connectionsList[current] {
-			neighbor := edge.List[1]
+			neighbor := edge.Other(current)
 
 			// Create a copy of visited map for this path
 			newVisited := make(map[*Node]bool)
@@ -1,4 +1,4 @@
-package main
+package graph
 
 import (
 	"container/heap"
@@ -9,7 +9,8 @@ type EdgeItem struct {
 	From   *Node
 	To     *Node
 	Weight float64
-	Index  int // Index in the heap
+	Edge   *Edge // the edge of the graph from From to To
+	Index  int   // Index in the heap
 }
 
 // PriorityQueue implements heap.Interface for EdgeItem
@@ -51,8 +52,9 @@ type PrimResult struct {
 	IsConnected bool
 }
 
-// prim implements Prim's algorithm for Minimum Spanning Tree using your graph structure
-func prim(g *GraphInfo, start *Node) *PrimResult {
+// Prim implements Prim's algorithm for Minimum Spanning Tree using your graph structure.
+// Parallel edges of a multigraph are all put into the queue, so only the lightest of them can get into the MST
+func Prim(g *GraphInfo, start *Node) *PrimResult {
 	result := &PrimResult{
 		MSTEdges:    make([]*Edge, 0),
 		TotalWeight: 0.0,
@@ -87,9 +89,10 @@ func prim(g *GraphInfo, start *Node) *PrimResult {
 	// Add all edges from start node to the priority queue
 	for _, edge := range g.connectionsList[start] {
 		heap.Push(&pq, &EdgeItem{
-			From:   edge.List[0],
-			To:     edge.List[1],
+			From:   start,
+			To:     edge.Other(start),
 			Weight: edge.Weight,
+			Edge:   edge,
 		})
 	}
 
@@ -105,11 +108,8 @@ func prim(g *GraphInfo, start *Node) *PrimResult {
 			continue
 		}
 
-		// Create the actual edge to add to MST
-		mstEdge := EdgeConstructor(minEdgeItem.From, minEdgeItem.To, minEdgeItem.Weight)
-
-		// Add this edge to MST
-		result.MSTEdges = append(result.MSTEdges, mstEdge)
+		// Add the edge of the graph to MST, so that the parallel edges of a multigraph can be told apart
+		result.MSTEdges = append(result.MSTEdges, minEdgeItem.Edge)
 		result.TotalWeight += minEdgeItem.Weight
 
 		// Mark the node as visited
@@ -117,12 +117,13 @@ func prim(g *GraphInfo, start *Node) *PrimResult {
 
 		// Add all edges from the new node to unvisited nodes
 		for _, edge := range g.connectionsList[minEdgeItem.To] {
-			neighbor := edge.List[1]
+			neighbor := edge.Other(minEdgeItem.To)
 			if !visited[neighbor] {
 				heap.Push(&pq, &EdgeItem{
-					From:   edge.List[0],
-					To:     edge.List[1],
+					From:   minEdgeItem.To,
+					To:     neighbor,
 					Weight: edge.Weight,
+					Edge:   edge,
 				})
 			}
 		}
@@ -134,8 +135,8 @@ func prim(g *GraphInfo, start *Node) *PrimResult {
 	return result
 }
 
-// primAllStarts runs Prim's algorithm from all possible start nodes and returns the best MST
-func primAllStarts(g *GraphInfo) *PrimResult {
+// PrimAllStarts runs Prim's algorithm from all possible start nodes and returns the best MST
+func PrimAllStarts(g *GraphInfo) *PrimResult {
 	if len(g.nodes) == 0 {
 		return &PrimResult{IsConnected: true}
 	}
@@ -143,7 +144,7 @@ func primAllStarts(g *GraphInfo) *PrimResult {
 	var bestResult *PrimResult
 
 	for _, start := range g.nodes {
-		result := prim(g, start)
+		result := Prim(g, start)
 
 		// If this is the first valid result or better than current best
 		if result.IsConnected && (bestResult == nil || result.TotalWeight < bestResult.TotalWeight) {
@@ -153,7 +154,7 @@ func primAllStarts(g *GraphInfo) *PrimResult {
 
 	if bestResult == nil {
 		// No connected MST found, return result from first node
-		return prim(g, g.nodes[0])
+		return Prim(g, g.nodes[0])
 	}
 
 	return bestResult
@@ -1,4 +1,4 @@
-package main
+package graph
 
 import (
 	"container/heap"
@@ -46,9 +46,9 @@ func (pq *DistancePriorityQueue) Pop() interface{} {
 	return item
 }
 
-// afindVerticesWithinDistance находит все вершины ориентированного графа,
+// FindVerticesWithinDistance находит все вершины ориентированного графа,
 // расстояние от которых до заданной вершины не более N (с учётом весов рёбер)
-func findVerticesWithinDistance(g *GraphInfo, start *Node, maxDistance float64) []*Node {
+func FindVerticesWithinDistance(g *GraphInfo, start *Node, maxDistance float64) []*Node {
 	if !g.isOriented {
 		return nil // Только для ориентированных графов
 	}
@@ -90,7 +90,7 @@ func findVerticesWithinDistance(g *GraphInfo, start *Node, maxDistance float64)
 
 		// Обрабатываем всех соседей
 		for _, edge := range g.connectionsList[current] {
-			neighbor := edge.List[1]
+			neighbor := edge.Other(current)
 			weight := edge.Weight
 
 			// Если граф невзвешенный, используем вес 1
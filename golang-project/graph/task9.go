@@ -1,13 +1,12 @@
-package main
+package graph
 
 import (
-	"fmt"
 	"math"
 )
 
-// floydWarshallSimple реализует упрощённую версию алгоритма Флойда-Уоршелла
+// FloydWarshallSimple реализует упрощённую версию алгоритма Флойда-Уоршелла
 // Возвращает матрицу кратчайших расстояний и флаг наличия отрицательных циклов
-func floydWarshallSimple(g *GraphInfo) (map[*Node]map[*Node]float64, bool) {
+func FloydWarshallSimple(g *GraphInfo) (map[*Node]map[*Node]float64, bool) {
 	// Инициализация матрицы расстояний
 	dist := make(map[*Node]map[*Node]float64)
 
@@ -23,10 +22,11 @@ func floydWarshallSimple(g *GraphInfo) (map[*Node]map[*Node]float64, bool) {
 		}
 	}
 
-	// Заполнение начальных расстояний из рёбер графа
+	// Заполнение начальных расстояний из рёбер графа.
+	// Неориентированное ребро есть в списках обоих концов, ориентированное - только в списке начала
 	for u, edges := range g.connectionsList {
 		for _, edge := range edges {
-			v := edge.List[1]
+			v := edge.Other(u)
 			weight := edge.Weight
 
 			// Если граф невзвешенный, используем вес 1
@@ -64,26 +64,3 @@ func floydWarshallSimple(g *GraphInfo) (map[*Node]map[*Node]float64, bool) {
 
 	return dist, hasNegativeCycle
 }
-
-// printDistanceMatrix выводит матрицу расстояний в простом формате
-func printDistanceMatrix(nodes []*Node, dist map[*Node]map[*Node]float64) {
-	fmt.Println("Shortest Paths Matrix:")
-	fmt.Print("     ")
-	for _, node := range nodes {
-		fmt.Printf("%5v", fmt.Sprintf("%v", node.Value))
-	}
-	fmt.Println()
-
-	for _, u := range nodes {
-		fmt.Printf("%5v", fmt.Sprintf("%v", u.Value))
-		for _, v := range nodes {
-			d := dist[u][v]
-			if math.IsInf(d, 1) {
-				fmt.Printf("%5s", "INF")
-			} else {
-				fmt.Printf("%5.1f", d)
-			}
-		}
-		fmt.Println()
-	}
-}
@@ -0,0 +1,203 @@
+package graph
+
+import (
+	"bytes"
+	"math"
+	"strings"
+	"testing"
+)
+
+// roundTrip writes the graph in the text format and reads it back
+func roundTrip(t *testing.T, g *GraphInfo) (*GraphInfo, string) {
+	t.Helper()
+	var buf bytes.Buffer
+	if err := Encode(g, &buf, "text"); err != nil {
+		t.Fatalf("encode: %v", err)
+	}
+	back, err := Decode(strings.NewReader(buf.String()), "text")
+	if err != nil {
+		t.Fatalf("decode of\n%s: %v", buf.String(), err)
+	}
+	return back, buf.String()
+}
+
+// assertSameGraph checks that b has the type, the vertices in the same order, the edges, the weights
+// and the attributes of a
+func assertSameGraph(t *testing.T, a, b *GraphInfo) {
+	t.Helper()
+	if a.IsOriented() != b.IsOriented() || a.IsWeighted() != b.IsWeighted() ||
+		a.IsMultigraph() != b.IsMultigraph() || a.IsMixed() != b.IsMixed() {
+		t.Fatalf("type: oriented %v/%v, weighted %v/%v, multi %v/%v, mixed %v/%v",
+			a.IsOriented(), b.IsOriented(), a.IsWeighted(), b.IsWeighted(),
+			a.IsMultigraph(), b.IsMultigraph(), a.IsMixed(), b.IsMixed())
+	}
+
+	nodesA, nodesB := a.Nodes(), b.Nodes()
+	if len(nodesA) != len(nodesB) {
+		t.Fatalf("%d vertices, want %d", len(nodesB), len(nodesA))
+	}
+	for i := range nodesA {
+		if nodesA[i].Value != nodesB[i].Value {
+			t.Errorf("vertex %d is %q, want %q", i, nodesB[i].Value, nodesA[i].Value)
+		}
+		assertSameAttributes(t, "vertex "+formatValue(nodesA[i]), nodesA[i].Attributes, nodesB[i].Attributes)
+	}
+
+	edgesA, edgesB := GetAllEdges(a), GetAllEdges(b)
+	if len(edgesA) != len(edgesB) {
+		t.Fatalf("%d edges, want %d", len(edgesB), len(edgesA))
+	}
+	for i := range edgesA {
+		ea, eb := edgesA[i], edgesB[i]
+		name := formatValue(ea.List[0]) + " " + formatValue(ea.List[1])
+		if ea.List[0].Value != eb.List[0].Value || ea.List[1].Value != eb.List[1].Value {
+			t.Errorf("edge %d is %v-%v, want %s", i, eb.List[0].Value, eb.List[1].Value, name)
+		}
+		if ea.IsOriented() != eb.IsOriented() {
+			t.Errorf("edge %s: oriented %v, want %v", name, eb.IsOriented(), ea.IsOriented())
+		}
+		if math.Float64bits(ea.Weight) != math.Float64bits(eb.Weight) {
+			t.Errorf("edge %s: weight %v, want %v", name, eb.Weight, ea.Weight)
+		}
+		assertSameAttributes(t, "edge "+name, ea.Attributes, eb.Attributes)
+	}
+}
+
+func assertSameAttributes(t *testing.T, name string, a, b Attributes) {
+	t.Helper()
+	if len(a) != len(b) {
+		t.Errorf("%s: attributes %v, want %v", name, b, a)
+		return
+	}
+	for key, value := range a {
+		if got, ok := b[key]; !ok || got != value {
+			t.Errorf("%s: attribute %q is %q, want %q", name, key, got, value)
+		}
+	}
+}
+
+func TestTextRoundTrip(t *testing.T) {
+	tests := []struct {
+		name string
+		text string
+	}{
+		{"weights", `TYPE: DIRECTED WEIGHTED
+VERTICES: A,B,C,D,E
+EDGES:
+A->B: 0.125
+B->C: 1e-9
+C->D: -0
+D->E: 1e21
+E->A: -3.5
+`},
+		{"quoted names", `TYPE: UNDIRECTED UNWEIGHTED
+VERTICES: "New-York","A, B","say \"hi\"","x->y","with space",plain
+EDGES:
+"New-York"-"A, B"
+"say \"hi\""-"x->y"
+"with space"-plain
+`},
+		{"mixed", `TYPE: MIXED WEIGHTED
+VERTICES: A,B,C
+EDGES:
+A->B: 1
+B-C: 2
+C->A: 3
+A-C: 4
+`},
+		{"multi", `TYPE: UNDIRECTED WEIGHTED MULTI
+VERTICES: A,B,C
+EDGES:
+A-B: 1
+A-B: 5
+A-B: 1
+B-C: 2
+C-C: 0
+`},
+		{"attributes", `TYPE: DIRECTED WEIGHTED MULTI
+VERTICES: A,B,C
+ATTRIBUTES:
+A [color=red, label="first, of all", x=1.5]
+C ["odd key"=v, "a=b"="line\nbreak"]
+EDGES:
+A->B: 2 [color=blue, note="say \"hi\""]
+A->B: 3 [style=dashed]
+B->C: 0 ["k]"="]", tab="a\tb"]
+`},
+		{"isolated vertices", `TYPE: UNDIRECTED UNWEIGHTED
+VERTICES: C,A,B
+EDGES:
+`},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			g, err := Decode(strings.NewReader(test.text), "text")
+			if err != nil {
+				t.Fatalf("decode: %v", err)
+			}
+			back, encoded := roundTrip(t, g)
+			assertSameGraph(t, g, back)
+
+			// Writing the graph read back gives the same text
+			if _, again := roundTrip(t, back); again != encoded {
+				t.Errorf("the text changed after a second round trip:\n%s\nwant\n%s", again, encoded)
+			}
+		})
+	}
+}
+
+func TestTextRoundTripBuiltGraph(t *testing.T) {
+	g := GraphConstructor(true, true)
+	g.SetMixed(true)
+	weights := []float64{0.125, 1e-9, math.Copysign(0, -1), 1e21, 0}
+	values := []string{"A", "B, C", "D \"E\"", "F G", "H"}
+	for i, weight := range weights {
+		AddEdgeBetweenNodes(g, values[i], values[(i+1)%len(values)], weight)
+	}
+	AddNonOrientedEdgeBetweenNodes(g, "A", "H", 7)
+	g.Nodes()[1].Attributes.Set("note", "line\nbreak")
+	edge := GetAllEdges(g)[0]
+	edge.Attributes.Set("a b", "=,]")
+
+	back, _ := roundTrip(t, g)
+	assertSameGraph(t, g, back)
+	for i, edge := range GetAllEdges(g) {
+		if math.Signbit(edge.Weight) && edge.Weight == 0 {
+			if w := GetAllEdges(back)[i].Weight; !math.Signbit(w) {
+				t.Errorf("weight -0 came back as %v", w)
+			}
+		}
+	}
+}
+
+func TestTextRoundTripRational(t *testing.T) {
+	text := `TYPE: UNDIRECTED WEIGHTED
+VERTICES: A,B,C,D
+EDGES:
+A-B: 1/3
+B-C: 0.125
+C-D: -2/7
+`
+	g, err := TypedGraphDecode[string, Rational](strings.NewReader(text), "text")
+	if err != nil {
+		t.Fatalf("decode: %v", err)
+	}
+
+	var buf bytes.Buffer
+	if err := Encode(g.Info(), &buf, "text"); err != nil {
+		t.Fatalf("encode: %v", err)
+	}
+	back, err := TypedGraphDecode[string, Rational](strings.NewReader(buf.String()), "text")
+	if err != nil {
+		t.Fatalf("decode of\n%s: %v", buf.String(), err)
+	}
+	assertSameGraph(t, g.Info(), back.Info())
+
+	want := []Rational{NewRational(1, 3), NewRational(1, 8), NewRational(-2, 7)}
+	for i, edge := range GetAllEdges(back.Info()) {
+		if got := back.Weight(edge); got.Rat().Cmp(want[i].Rat()) != 0 {
+			t.Errorf("edge %d: weight %v, want %v", i, got, want[i])
+		}
+	}
+}
@@ -0,0 +1,109 @@
+package graph
+
+import (
+	"fmt"
+	"io"
+	"math"
+	"strings"
+)
+
+// Drawing graphs as TikZ pictures for LaTeX documents. The file is a tikzpicture environment
+// ready to be \input in a figure, it needs only \usepackage{tikz}. The drawing is the same as of
+// EncodeCeTZ: the vertices at the positions of the layout, the weights on the edges and the highlighted
+// edges thick in the colors of their sets
+
+// WriteTikZ saves the graph as a TikZ picture to a LaTeX file. diagram may be nil
+func WriteTikZ(graph *GraphInfo, path string, diagram *Diagram) error {
+	return WriteFileWith(path, func(w io.Writer) error {
+		return EncodeTikZ(graph, w, diagram)
+	})
+}
+
+// EncodeTikZ writes the graph as a TikZ picture. diagram may be nil
+func EncodeTikZ(graph *GraphInfo, w io.Writer, diagram *Diagram) error {
+	vertices, byNode := diagramVertices(graph, diagram)
+	radius := vertexRadius(vertices)
+	edges := diagramEdges(graph, diagram, byNode, radius)
+
+	lines := []string{
+		fmt.Sprintf("%% %d vertices, %d edges", len(vertices), len(edges)),
+		fmt.Sprintf(`\begin{tikzpicture}[>=stealth, vertex/.style={circle, draw, fill=white, minimum size=%scm, inner sep=1pt}, `+
+			`weight/.style={fill=white, inner sep=1pt, font=\small}]`, formatLength(2*radius)),
+	}
+	for _, v := range vertices {
+		style := "vertex"
+		if v.fill != "" {
+			style += ", fill=" + tikzColor(v.fill)
+		}
+		if v.note != "" {
+			style += fmt.Sprintf(`, label={[font=\small]above right:{%s}}`, latexEscape(v.note))
+		}
+		lines = append(lines, fmt.Sprintf(`  \node[%s] (%s) at %s {%s};`, style, v.name, tikzPoint(v.position), latexEscape(v.label)))
+	}
+
+	for _, e := range edges {
+		var style []string
+		if e.edge.IsOriented() {
+			style = append(style, "->")
+		}
+		if c := e.color(); c != "" {
+			style = append(style, c, "very thick")
+		}
+
+		var path string
+		switch {
+		case e.isLoop():
+			angle := math.Round(e.loopAngle * 180 / math.Pi)
+			out, in := math.Mod(angle+30+360, 360), math.Mod(angle-30+360, 360)
+			path = fmt.Sprintf("to[loop, out=%.0f, in=%.0f, looseness=%d]", out, in, 8+4*e.loop)
+		case e.bend != 0:
+			p, q := e.from.position, e.to.position
+			length := math.Max(math.Hypot(q.X-p.X, q.Y-p.Y), 1e-9)
+			// The middle of a curve bent by an angle is at about 3/8 of its length times the tangent of the angle
+			angle := math.Atan(8*e.bend/(3*length)) * 180 / math.Pi
+			if angle > 0 {
+				path = fmt.Sprintf("to[bend left=%.0f]", angle)
+			} else {
+				path = fmt.Sprintf("to[bend right=%.0f]", -angle)
+			}
+		default:
+			path = "--"
+		}
+		if e.label != "" {
+			path += fmt.Sprintf(" node[weight] {%s}", latexEscape(e.label))
+		}
+
+		draw := `\draw`
+		if len(style) > 0 {
+			draw += "[" + strings.Join(style, ", ") + "]"
+		}
+		lines = append(lines, fmt.Sprintf("  %s (%s) %s (%s);", draw, e.from.name, path, e.to.name))
+	}
+	lines = append(lines, `\end{tikzpicture}`)
+
+	_, err := io.WriteString(w, strings.Join(lines, "\n")+"\n")
+	return err
+}
+
+// tikzColor returns the color "#rrggbb" in the syntax of xcolor
+func tikzColor(hex string) string {
+	var r, g, b int
+	fmt.Sscanf(hex, "#%02x%02x%02x", &r, &g, &b)
+	return fmt.Sprintf("{rgb,255:red,%d;green,%d;blue,%d}", r, g, b)
+}
+
+func tikzPoint(p Point) string {
+	return "(" + formatLength(p.X) + ", " + formatLength(p.Y) + ")"
+}
+
+// latexEscape escapes the special characters of LaTeX in s
+func latexEscape(s string) string {
+	return strings.NewReplacer(
+		`\`, `\textbackslash{}`,
+		`{`, `\{`, `}`, `\}`,
+		`#`, `\#`, `$`, `\$`, `%`, `\%`, `&`, `\&`, `_`, `\_`,
+		`~`, `\textasciitilde{}`, `^`, `\textasciicircum{}`,
+		"\n", " ",
+		"−∞", `$-\infty$`, "−", `$-$`, "∞", `$\infty$`, // the notes of DistanceNotes
+	).Replace(s)
+}
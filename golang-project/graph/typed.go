@@ -0,0 +1,253 @@
+package graph
+
+import (
+	"fmt"
+	"io"
+	"math/big"
+	"strconv"
+)
+
+// Number is a type of edge weights of a typed graph
+type Number interface {
+	int | int64 | float64 | Rational
+}
+
+// Rational is an exact fraction, e.g. 1/3. The zero value is 0
+type Rational struct {
+	rat *big.Rat
+}
+
+// NewRational returns num/den. Panics if den is 0
+func NewRational(num int64, den int64) Rational {
+	return Rational{rat: big.NewRat(num, den)}
+}
+
+// ParseRational reads a fraction ("3/4"), an integer or a decimal number ("0.125", "1e-9") exactly
+func ParseRational(s string) (Rational, error) {
+	rat, ok := new(big.Rat).SetString(s)
+	if !ok {
+		return Rational{}, fmt.Errorf("invalid rational number '%s'", s)
+	}
+	return Rational{rat: rat}, nil
+}
+
+// Rat returns the value as a new big.Rat
+func (r Rational) Rat() *big.Rat {
+	if r.rat == nil {
+		return new(big.Rat)
+	}
+	return new(big.Rat).Set(r.rat)
+}
+
+// Float64 returns the nearest float64 value
+func (r Rational) Float64() float64 {
+	f, _ := r.Rat().Float64()
+	return f
+}
+
+// String returns "num/den", or just "num" for integers
+func (r Rational) String() string {
+	return r.Rat().RatString()
+}
+
+// Graph is a GraphInfo whose vertex values have type K and edge weights have type W.
+// The text loader reads vertex values and weights into these types, so the integer 1
+// and the string "1" are never the same vertex. The algorithms work with Info(),
+// which sees the weights as float64
+type Graph[K comparable, W Number] struct {
+	info *GraphInfo
+}
+
+// TypedGraphConstructor returns an empty typed graph of the given type
+func TypedGraphConstructor[K comparable, W Number](isOriented bool, isWeighted bool) *Graph[K, W] {
+	g := &Graph[K, W]{info: GraphConstructor(isOriented, isWeighted)}
+	g.setParsers()
+	return g
+}
+
+// TypedGraphFromFileConstructor reads a typed graph from the text file at path.
+// Returns an error if the file can not be read or a value does not have the declared type
+func TypedGraphFromFileConstructor[K comparable, W Number](path string) (*Graph[K, W], error) {
+	g := &Graph[K, W]{info: GraphEmptyConstructor()}
+	g.setParsers()
+	if _, err := readGraphFile(g.info, path, false); err != nil {
+		return nil, err
+	}
+	return g, nil
+}
+
+// TypedGraphDecode reads a typed graph in the named format from r, an empty format is detected from the content (see Decode).
+// Returns an error if the input can not be read or a value does not have the declared type
+func TypedGraphDecode[K comparable, W Number](r io.Reader, format string) (*Graph[K, W], error) {
+	g := &Graph[K, W]{info: GraphEmptyConstructor()}
+	g.setParsers()
+	if err := decodeInto(g.info, r, format, inputName); err != nil {
+		return nil, err
+	}
+	return g, nil
+}
+
+func (g *Graph[K, W]) setParsers() {
+	g.info.parseValue = func(valueStr string) (interface{}, error) {
+		return parseKey[K](valueStr)
+	}
+	g.info.parseWeight = func(weightStr string) (float64, interface{}, error) {
+		weight, err := parseNumber[W](weightStr)
+		if err != nil {
+			return 0, nil, err
+		}
+		return numberToFloat(weight), weight, nil
+	}
+}
+
+// Info returns the untyped view of the graph for the algorithms
+func (g *Graph[K, W]) Info() *GraphInfo {
+	return g.info
+}
+
+// AddVertex adds a vertex with the given key. Returns an error if it already exists
+func (g *Graph[K, W]) AddVertex(key K) (*Node, error) {
+	n := NodeConstructor(key)
+	if err := AddVertex(g.info, n); err != nil {
+		return nil, err
+	}
+	return n, nil
+}
+
+// Node returns the vertex with the given key or nil if there is none
+func (g *Graph[K, W]) Node(key K) *Node {
+	return FindNodeByValue(g.info, key)
+}
+
+// Key returns the key of a vertex of the graph
+func (g *Graph[K, W]) Key(n *Node) K {
+	key, _ := n.Value.(K)
+	return key
+}
+
+// AddEdge adds an edge between the vertices with the given keys, creating the vertices
+// if they do not exist yet. The edge is oriented if the graph is
+func (g *Graph[K, W]) AddEdge(from K, to K, weight W) error {
+	return g.addEdge(from, to, weight, g.info.isOriented)
+}
+
+// AddNonOrientedEdge adds a non-oriented edge between the vertices with the given keys.
+// Used to add two-way edges to a mixed graph
+func (g *Graph[K, W]) AddNonOrientedEdge(from K, to K, weight W) error {
+	return g.addEdge(from, to, weight, false)
+}
+
+func (g *Graph[K, W]) addEdge(from K, to K, weight W, oriented bool) error {
+	fromNode := g.Node(from)
+	if fromNode == nil {
+		fromNode, _ = g.AddVertex(from)
+	}
+	toNode := g.Node(to)
+	if toNode == nil {
+		toNode, _ = g.AddVertex(to)
+	}
+
+	if !g.info.isWeighted {
+		var zero W
+		weight = zero
+	}
+
+	if oriented {
+		_, err := addEdge(g.info, fromNode, toNode, numberToFloat(weight), weight)
+		return err
+	}
+	return addNonOrientedEdge(g.info, fromNode, toNode, numberToFloat(weight), weight)
+}
+
+// Weight returns the weight of an edge of the graph
+func (g *Graph[K, W]) Weight(e *Edge) W {
+	if weight, ok := e.exact.(W); ok {
+		return weight
+	}
+	return numberFromFloat[W](e.Weight)
+}
+
+// parseKey converts the text of a vertex value into K
+func parseKey[K comparable](valueStr string) (K, error) {
+	var key K
+	var value interface{}
+	var err error
+
+	switch any(key).(type) {
+	case string:
+		value = valueStr
+	case int:
+		value, err = strconv.Atoi(valueStr)
+	case int64:
+		value, err = strconv.ParseInt(valueStr, 10, 64)
+	case uint32:
+		var v uint64
+		v, err = strconv.ParseUint(valueStr, 10, 32)
+		value = uint32(v)
+	case float64:
+		value, err = strconv.ParseFloat(valueStr, 64)
+	case bool:
+		value, err = strconv.ParseBool(valueStr)
+	default:
+		return key, fmt.Errorf("vertex values of type %T can not be read from text", key)
+	}
+
+	if err != nil {
+		return key, fmt.Errorf("invalid vertex value '%s' for type %T", valueStr, key)
+	}
+	return value.(K), nil
+}
+
+// parseNumber converts the text of an edge weight into W
+func parseNumber[W Number](weightStr string) (W, error) {
+	var weight W
+	var value interface{}
+	var err error
+
+	switch any(weight).(type) {
+	case int:
+		value, err = strconv.Atoi(weightStr)
+	case int64:
+		value, err = strconv.ParseInt(weightStr, 10, 64)
+	case float64:
+		value, err = strconv.ParseFloat(weightStr, 64)
+	case Rational:
+		value, err = ParseRational(weightStr)
+	}
+
+	if err != nil {
+		return weight, fmt.Errorf("invalid weight '%s' for type %T", weightStr, weight)
+	}
+	return value.(W), nil
+}
+
+func numberToFloat[W Number](weight W) float64 {
+	switch w := any(weight).(type) {
+	case int:
+		return float64(w)
+	case int64:
+		return float64(w)
+	case float64:
+		return w
+	case Rational:
+		return w.Float64()
+	}
+	return 0
+}
+
+func numberFromFloat[W Number](weight float64) W {
+	var value interface{}
+	var zero W
+	switch any(zero).(type) {
+	case int:
+		value = int(weight)
+	case int64:
+		value = int64(weight)
+	case float64:
+		value = weight
+	case Rational:
+		rat, _ := new(big.Rat).SetString(strconv.FormatFloat(weight, 'g', -1, 64))
+		value = Rational{rat: rat}
+	}
+	return value.(W)
+}
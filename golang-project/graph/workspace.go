@@ -0,0 +1,225 @@
+package graph
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"time"
+)
+
+// A workspace file keeps several graphs of a session together, as JSON:
+//
+//	{
+//	  "version": 1,
+//	  "saved": "2024-05-01T12:00:00Z",
+//	  "active": 0,            // the index of the graph being worked on, -1 if none
+//	  "autosave": true,       // save the workspace on exit without asking
+//	  "graphs": [
+//	    {
+//	      "name": "roads",
+//	      "source": "roads.dot",                       // the file the graph was loaded from or saved to
+//	      "metadata": {"author": "me"},
+//	      "graph": {"oriented": true, "nodes": [...], "edges": [...]}
+//	    }
+//	  ]
+//	}
+//
+// Every graph is in the JSON format of a single graph (see MarshalJSON)
+
+// workspaceVersion is the version of the workspace format written by EncodeWorkspace
+const workspaceVersion = 1
+
+// ErrWorkspace is returned when a workspace file is read as a single graph
+var ErrWorkspace = errors.New("the file is a workspace of several graphs, not a graph: open it as a workspace")
+
+// workspaceKeys are the top-level keys of a workspace file, a JSON graph has none of them
+var workspaceKeys = map[string]bool{"version": true, "saved": true, "active": true, "autosave": true, "graphs": true}
+
+// detectWorkspace reports whether head, the beginning of a file, is a JSON object with a top-level key of a workspace
+func detectWorkspace(head []byte) bool {
+	decoder := json.NewDecoder(bytes.NewReader(bytes.TrimPrefix(head, []byte("\ufeff"))))
+	if tok, err := decoder.Token(); err != nil || tok != json.Delim('{') {
+		return false
+	}
+	for decoder.More() {
+		tok, err := decoder.Token()
+		if err != nil {
+			return false
+		}
+		if key, _ := tok.(string); workspaceKeys[key] {
+			return true
+		}
+		// The value of a key may be cut off at the end of head
+		var value json.RawMessage
+		if decoder.Decode(&value) != nil {
+			return false
+		}
+	}
+	return false
+}
+
+// Workspace is a set of named graphs saved and opened together
+type Workspace struct {
+	Graphs   []*WorkspaceGraph
+	Active   int       // the index of the active graph in Graphs, -1 if there is none
+	Autosave bool      // whether the workspace is saved on exit without asking
+	Saved    time.Time // when the workspace was written last
+}
+
+// WorkspaceGraph is a graph of a workspace with its name and metadata
+type WorkspaceGraph struct {
+	Name     string     `json:"name"`
+	Source   string     `json:"source,omitempty"` // the file the graph was loaded from or saved to, if any
+	Metadata Attributes `json:"metadata,omitempty"`
+	Graph    *GraphInfo `json:"graph"`
+}
+
+type jsonWorkspace struct {
+	Version  int               `json:"version"`
+	Saved    time.Time         `json:"saved"`
+	Active   int               `json:"active"`
+	Autosave bool              `json:"autosave,omitempty"`
+	Graphs   []*WorkspaceGraph `json:"graphs"`
+}
+
+// NewWorkspace returns an empty workspace without an active graph
+func NewWorkspace() *Workspace {
+	return &Workspace{Graphs: make([]*WorkspaceGraph, 0), Active: -1}
+}
+
+// ReadWorkspace reads a workspace from the file at path
+func ReadWorkspace(path string) (*Workspace, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return nil, fmt.Errorf("Error opening file: %v", err)
+	}
+	defer file.Close()
+
+	return DecodeWorkspace(bufio.NewReader(file), path)
+}
+
+// WriteWorkspace saves the workspace to the file at path. The file is written next to it first and then
+// renamed, so a crash while saving does not leave a half-written workspace. Sets Saved to the current time
+func WriteWorkspace(workspace *Workspace, path string) error {
+	temp, err := os.CreateTemp(filepath.Dir(path), "."+filepath.Base(path)+".*")
+	if err != nil {
+		return fmt.Errorf("error creating file: %v", err)
+	}
+	defer os.Remove(temp.Name())
+
+	saved := workspace.Saved
+	workspace.Saved = time.Now()
+	writer := bufio.NewWriter(temp)
+	err = EncodeWorkspace(workspace, writer)
+	if err == nil {
+		err = writer.Flush()
+	}
+	if closeErr := temp.Close(); err == nil {
+		err = closeErr
+	}
+	if err == nil {
+		err = os.Rename(temp.Name(), path)
+	}
+	if err != nil {
+		workspace.Saved = saved
+		return err
+	}
+	return nil
+}
+
+// EncodeWorkspace writes the workspace as an indented JSON document
+func EncodeWorkspace(workspace *Workspace, w io.Writer) error {
+	doc := jsonWorkspace{
+		Version:  workspaceVersion,
+		Saved:    workspace.Saved.UTC().Truncate(time.Second),
+		Active:   workspace.Active,
+		Autosave: workspace.Autosave,
+		Graphs:   workspace.Graphs,
+	}
+	if doc.Graphs == nil {
+		doc.Graphs = make([]*WorkspaceGraph, 0)
+	}
+	encoder := json.NewEncoder(w)
+	encoder.SetIndent("", "  ")
+	return encoder.Encode(doc)
+}
+
+// DecodeWorkspace reads a workspace. name is the name of the file for the errors
+func DecodeWorkspace(r io.Reader, name string) (*Workspace, error) {
+	data, err := io.ReadAll(r)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %v", name, err)
+	}
+
+	var doc jsonWorkspace
+	if err := json.Unmarshal(data, &doc); err != nil {
+		var syntaxErr *json.SyntaxError
+		if errors.As(err, &syntaxErr) {
+			line, column := positionOf(data, syntaxErr.Offset)
+			return nil, &ParseError{Path: name, Line: line, Column: column, Msg: syntaxErr.Error()}
+		}
+		return nil, fmt.Errorf("%s: %v", name, err)
+	}
+	if !detectWorkspace(data) {
+		return nil, fmt.Errorf("%s: the file is not a workspace, load it as a graph", name)
+	}
+	if doc.Version < 1 || doc.Version > workspaceVersion {
+		return nil, fmt.Errorf("%s: unsupported workspace version %d", name, doc.Version)
+	}
+
+	workspace := &Workspace{Graphs: doc.Graphs, Active: doc.Active, Autosave: doc.Autosave, Saved: doc.Saved}
+	if workspace.Graphs == nil {
+		workspace.Graphs = make([]*WorkspaceGraph, 0)
+	}
+	for i, g := range workspace.Graphs {
+		if g == nil || g.Graph == nil {
+			return nil, fmt.Errorf("%s: graph %d has no \"graph\"", name, i+1)
+		}
+	}
+	if workspace.Active < -1 || workspace.Active >= len(workspace.Graphs) {
+		return nil, fmt.Errorf("%s: active graph %d is out of range", name, workspace.Active)
+	}
+	return workspace, nil
+}
+
+// Add appends the graph to the workspace under the name, made unique with UniqueName, and makes it active.
+// Returns the new entry
+func (w *Workspace) Add(name string, graph *GraphInfo) *WorkspaceGraph {
+	entry := &WorkspaceGraph{Name: w.UniqueName(name), Graph: graph}
+	w.Graphs = append(w.Graphs, entry)
+	w.Active = len(w.Graphs) - 1
+	return entry
+}
+
+// ActiveGraph returns the active graph of the workspace, nil if there is none
+func (w *Workspace) ActiveGraph() *WorkspaceGraph {
+	if w.Active < 0 || w.Active >= len(w.Graphs) {
+		return nil
+	}
+	return w.Graphs[w.Active]
+}
+
+// ByName returns the first graph with the name, nil if there is none
+func (w *Workspace) ByName(name string) *WorkspaceGraph {
+	for _, g := range w.Graphs {
+		if g.Name == name {
+			return g
+		}
+	}
+	return nil
+}
+
+// UniqueName returns name, or name with the smallest number " (2)", " (3)", ... that no graph of
+// the workspace has
+func (w *Workspace) UniqueName(name string) string {
+	unique := name
+	for i := 2; w.ByName(unique) != nil; i++ {
+		unique = fmt.Sprintf("%s (%d)", name, i)
+	}
+	return unique
+}
@@ -0,0 +1,98 @@
+package graph
+
+import (
+	"bytes"
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+// testWorkspace returns a workspace of two graphs, A was removed from the first one
+func testWorkspace() *Workspace {
+	workspace := NewWorkspace()
+	roads := GraphConstructor(false, true)
+	AddNonOrientedEdgeBetweenNodes(roads, "A", "B", 1)
+	AddNonOrientedEdgeBetweenNodes(roads, "B", "C", 2)
+	RemoveVertex(roads, FindNodeByValue(roads, "A"))
+	workspace.Add("roads", roads).Source = "roads.dot"
+	workspace.Add("empty", GraphConstructor(true, false))
+	return workspace
+}
+
+func TestWorkspaceRoundTrip(t *testing.T) {
+	workspace := testWorkspace()
+	var buf bytes.Buffer
+	if err := EncodeWorkspace(workspace, &buf); err != nil {
+		t.Fatalf("encode: %v", err)
+	}
+	back, err := DecodeWorkspace(strings.NewReader(buf.String()), inputName)
+	if err != nil {
+		t.Fatalf("decode of\n%s: %v", buf.String(), err)
+	}
+
+	if len(back.Graphs) != 2 || back.Active != 1 || back.Graphs[0].Source != "roads.dot" {
+		t.Fatalf("got %d graphs, active %d, source %q", len(back.Graphs), back.Active, back.Graphs[0].Source)
+	}
+	for i, entry := range workspace.Graphs {
+		assertSameGraph(t, entry.Graph, back.Graphs[i].Graph)
+		for _, node := range entry.Graph.Nodes() {
+			if got := FindNodeByValue(back.Graphs[i].Graph, node.Value).Id; got != node.Id {
+				t.Errorf("graph %s, vertex %v: id %d, want %d", entry.Name, node.Value, got, node.Id)
+			}
+		}
+	}
+}
+
+func TestWorkspaceIsNotAGraph(t *testing.T) {
+	var buf bytes.Buffer
+	if err := EncodeWorkspace(testWorkspace(), &buf); err != nil {
+		t.Fatalf("encode: %v", err)
+	}
+	dir := t.TempDir()
+	withExtension := filepath.Join(dir, "session.json")
+	withoutExtension := filepath.Join(dir, "session")
+	for _, path := range []string{withExtension, withoutExtension} {
+		if err := os.WriteFile(path, buf.Bytes(), 0o644); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	reads := map[string]func() error{
+		"decode as json": func() error {
+			_, err := Decode(bytes.NewReader(buf.Bytes()), "json")
+			return err
+		},
+		"decode detected": func() error {
+			_, err := Decode(bytes.NewReader(buf.Bytes()), "")
+			return err
+		},
+		"read by extension": func() error {
+			_, err := ReadFile(withExtension, "")
+			return err
+		},
+		"read detected": func() error {
+			_, err := ReadFile(withoutExtension, "")
+			return err
+		},
+	}
+	for name, read := range reads {
+		if err := read(); !errors.Is(err, ErrWorkspace) {
+			t.Errorf("%s: error %v, want ErrWorkspace", name, err)
+		}
+	}
+
+	// JSON that is neither a graph nor a workspace
+	if _, err := Decode(strings.NewReader(`{"name": "x"}`), "json"); err == nil || !strings.Contains(err.Error(), "not a JSON graph") {
+		t.Errorf("error %v, want not a JSON graph", err)
+	}
+
+	// A graph is not a workspace either
+	g := GraphConstructor(true, false)
+	AddEdgeBetweenNodes(g, "A", "B", 0)
+	_, err := DecodeWorkspace(strings.NewReader(encodeString(t, g, "json")), inputName)
+	if err == nil || !strings.Contains(err.Error(), "not a workspace") {
+		t.Errorf("error %v, want not a workspace", err)
+	}
+}
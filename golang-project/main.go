@@ -1,6 +0,0 @@
-package main
-
-func main() {
-	cli := NewCLI()
-	cli.Run()
-}